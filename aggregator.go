@@ -0,0 +1,146 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+/*
+An Aggregator collects errors from a loop or batch so that they can be reported together.
+It keeps at most a fixed number of errors and only counts the rest, so a large batch that
+mostly fails can't exhaust memory.
+
+	agg := cat.NewAggregatorN(10)
+	for _, item := range items {
+		agg.Add(process(item))
+	}
+	cat.Catch(agg.Err(), "batch failed")
+
+Aggregators are safe for concurrent use.
+*/
+type Aggregator struct {
+	mutex sync.Mutex
+	max   int
+	errs  []error
+	count int
+}
+
+// Creates an [Aggregator] that keeps the first `max` errors. If `max` is zero or less,
+// all errors are kept.
+func NewAggregatorN(max int) *Aggregator {
+	return &Aggregator{max: max}
+}
+
+// Adds an error to the aggregator. Nil errors are ignored.
+func (a *Aggregator) Add(err error) {
+	if err == nil {
+		return
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.count++
+	if a.max <= 0 || len(a.errs) < a.max {
+		a.errs = append(a.errs, err)
+	}
+}
+
+// Returns the number of errors added, including those that weren't kept.
+func (a *Aggregator) Count() int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.count
+}
+
+// Returns the kept errors joined with errors.Join, or nil if no errors were added. If
+// errors were discarded, the message ends with "(and N more)".
+func (a *Aggregator) Err() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.count == 0 {
+		return nil
+	}
+
+	errs := append([]error{}, a.errs...)
+	if overflow := a.count - len(a.errs); overflow > 0 {
+		errs = append(errs, fmt.Errorf("(and %d more)", overflow))
+	}
+	return errors.Join(errs...)
+}
+
+/*
+Runs each check and catches if any of them fail. All failures are reported together,
+joined with errors.Join and annotated with `problem`. This is a lightweight alternative to
+an [Aggregator] for validating several related values inline:
+
+	cat.CatchAllOf("invalid user",
+		func() error { return validateName(user.Name) },
+		func() error { return validateEmail(user.Email) },
+	)
+
+Checks should return their errors rather than calling Catch, which would propagate
+immediately and skip the remaining checks.
+*/
+func CatchAllOf(problem string, checks ...func() error) {
+	var errs []error
+	for _, check := range checks {
+		if err := check(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		Catch(errors.Join(errs...), problem)
+	}
+}
+
+/*
+A Collector accumulates errors from steps that should keep going on failure, so that all
+of the problems are reported at the end:
+
+	var c cat.Collector
+	for _, f := range files {
+		c.Add(processFile(f), "processing "+f.Name())
+	}
+	cat.Catch(c.Result())
+
+Problems are applied the same way as in [Catch]. The zero value is ready to use, and
+Collectors are safe for concurrent use. See [Aggregator] for limiting the number of
+errors kept.
+*/
+type Collector struct {
+	mutex sync.Mutex
+	errs  []error
+}
+
+// Records `err` annotated with `problem`, if it's not nil.
+func (c *Collector) Add(err error, problem ...any) {
+	if err != nil {
+		c.Catch(err, problem...)
+	}
+}
+
+// Records the error that [Catch] would propagate for the arguments, instead of
+// propagating it. Returns true if the condition triggered.
+func (c *Collector) Catch(condition any, problem ...any) bool {
+	err := caught(condition, problem)
+	if err == nil {
+		return false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.errs = append(c.errs, err)
+	return true
+}
+
+// Returns the recorded errors joined with errors.Join, or nil if there are none.
+func (c *Collector) Result() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return errors.Join(c.errs...)
+}
@@ -0,0 +1,86 @@
+package errorcat_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// Errors beyond the limit are counted but not kept.
+func TestAggregatorN(t *testing.T) {
+	agg := cat.NewAggregatorN(2)
+	assert.NoError(t, agg.Err())
+
+	for i := 0; i < 5; i++ {
+		agg.Add(nil)
+		agg.Add(fmt.Errorf("item %d: %w", i, errTest))
+	}
+
+	assert.Equal(t, 5, agg.Count())
+	err := agg.Err()
+	assert.Equal(t, "item 0: test-error\nitem 1: test-error\n(and 3 more)", err.Error())
+	assert.ErrorIs(t, err, errTest)
+}
+
+// The aggregator reports everything when under the limit, or when there is no limit.
+func TestAggregatorUnderLimit(t *testing.T) {
+	agg := cat.NewAggregatorN(5)
+	agg.Add(errTest)
+	agg.Add(errTest2)
+	assert.Equal(t, "test-error\ntest-error2", agg.Err().Error())
+
+	agg = cat.NewAggregatorN(0)
+	for i := 0; i < 100; i++ {
+		agg.Add(errTest)
+	}
+	assert.Equal(t, 100, agg.Count())
+	assert.NotContains(t, agg.Err().Error(), "more")
+}
+
+// All failing checks are reported together with the shared problem.
+func TestCatchAllOf(t *testing.T) {
+	pass := func() error { return nil }
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchAllOf("invalid user", pass, pass)
+		cat.CatchAllOf("invalid user")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchAllOf("invalid user",
+			func() error { return errTest },
+			pass,
+			func() error { return errTest2 },
+		)
+		return nil
+	})
+	assert.EqualError(t, err, "invalid user: test-error\ntest-error2")
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, errTest2)
+}
+
+// Collectors keep going and report everything at the end.
+func TestCollector(t *testing.T) {
+	var c cat.Collector
+	assert.NoError(t, c.Result())
+
+	files := map[string]error{"a.txt": nil, "b.txt": errTest, "c.txt": errTest2}
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		c.Add(files[name], "processing "+name)
+	}
+	assert.False(t, c.Catch(false, "not triggered"))
+	assert.True(t, c.Catch(true, "bad header"))
+
+	err := cat.Guard(func(ct cat.Context) error {
+		ct.Catch(c.Result(), "batch failed")
+		return nil
+	})
+	assert.EqualError(t, err,
+		"batch failed: processing b.txt: test-error\nprocessing c.txt: test-error2\nbad header")
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, errTest2)
+}
@@ -0,0 +1,85 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"errors"
+	"sync"
+)
+
+// Returns an annotator that looks for an error of type T in the chain with errors.As. If
+// one is found, `handle` is called with it, and its result replaces the error. Otherwise,
+// the error passes through unchanged. This is for handling error types rather than
+// sentinel values, e.g.:
+//
+//	cat.AsAnnotator(func(e *json.SyntaxError) error {
+//		return badRequest(fmt.Sprintf("invalid JSON at offset %d", e.Offset))
+//	})
+//
+// Like other annotators, returning nil from `handle` ends the annotator chain.
+func AsAnnotator[T error](handle func(T) error) Annotator {
+	return func(err error) error {
+		var target T
+		if errors.As(err, &target) {
+			return handle(target)
+		}
+		return err
+	}
+}
+
+// Names of annotators disabled with DisableAnnotator.
+var disabledAnnotators sync.Map
+
+/*
+Gives an annotator a name so that it can be disabled at runtime with [DisableAnnotator],
+e.g., to see unredacted errors during a debugging session without editing every guard:
+
+	var redact = cat.NamedAnnotator("redact", redactSecrets)
+	...
+	cat.DisableAnnotator("redact")
+
+While disabled, the annotator passes errors through unchanged.
+*/
+func NamedAnnotator(name string, a Annotator) Annotator {
+	return func(err error) error {
+		if _, disabled := disabledAnnotators.Load(name); disabled {
+			return err
+		}
+		return a(err)
+	}
+}
+
+// Disables the annotators created by [NamedAnnotator] with the given name.
+func DisableAnnotator(name string) {
+	disabledAnnotators.Store(name, struct{}{})
+}
+
+// Re-enables the annotators disabled with [DisableAnnotator].
+func EnableAnnotator(name string) {
+	disabledAnnotators.Delete(name)
+}
+
+// Returns an annotator that calls `fn` only if the error matches `target` with errors.Is.
+// Otherwise, the error passes through unchanged. These compose in the annotate list of
+// [Recover] or [Guard] in place of a single handler with if/else checks:
+//
+//	defer cat.Recover(&rerr,
+//		cat.WhenIs(ErrBadRequest, handleBadRequest),
+//		cat.WhenIs(ErrNotFound, handleNotFound),
+//	)
+//
+// Like other annotators, returning nil from `fn` ends the annotator chain.
+func WhenIs(target error, fn Annotator) Annotator {
+	return func(err error) error {
+		if errors.Is(err, target) {
+			return fn(err)
+		}
+		return err
+	}
+}
+
+// The same as [AsAnnotator], named to pair with [WhenIs].
+func WhenAs[T error](fn func(T) error) Annotator {
+	return AsAnnotator(fn)
+}
@@ -0,0 +1,146 @@
+package errorcat_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// AsAnnotator finds typed errors deep in the chain and transforms them.
+func TestAsAnnotator(t *testing.T) {
+	handler := cat.AsAnnotator(func(e *json.SyntaxError) error {
+		return fmt.Errorf("%w: invalid JSON at offset %d", errBadRequest, e.Offset)
+	})
+
+	err := cat.Guard(func(ct cat.Context) error {
+		var v any
+		err := json.Unmarshal([]byte(`{"a": }`), &v)
+		err = fmt.Errorf("decoding body: %w", err)
+		err = fmt.Errorf("reading request: %w", err)
+		ct.Catch(err, "handler failed")
+		return nil
+	}, handler)
+
+	assert.Equal(t, "bad request: invalid JSON at offset 7", err.Error())
+	assert.ErrorIs(t, err, errBadRequest)
+
+	// Other errors pass through.
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest, "handler failed")
+		return nil
+	}, handler)
+
+	assert.Equal(t, "handler failed: test-error", err.Error())
+}
+
+// Returning nil from the handler ends the annotator chain.
+func TestAsAnnotatorHandled(t *testing.T) {
+	var target *json.SyntaxError
+	err := cat.Guard(func(ct cat.Context) error {
+		var v any
+		ct.Catch(json.Unmarshal([]byte(`{`), &v))
+		return nil
+	}, cat.AsAnnotator(func(e *json.SyntaxError) error {
+		target = e
+		return nil
+	}), func(err error) error {
+		assert.Fail(t, "this should not be called")
+		return err
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, target)
+}
+
+// Summary annotators run last with the string annotations that were applied.
+func TestSummaryAnnotator(t *testing.T) {
+	var applied []string
+
+	err := cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest, "problem")
+		return nil
+	}, "first", cat.SummaryAnnotator(func(err error, a []string) error {
+		applied = a
+		return fmt.Errorf("summary (%d annotations): %w", len(a), err)
+	}), errTest2, "second", func(err error) error {
+		return fmt.Errorf("wrapped: %w", err)
+	})
+
+	assert.Equal(t, []string{"first", "second"}, applied)
+	assert.Equal(t, "summary (2 annotations): wrapped: second: test-error2: first: problem: test-error", err.Error())
+
+	// Summaries are not called if the chain is broken.
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest, "problem")
+		return nil
+	}, func(err error) error {
+		return nil
+	}, cat.SummaryAnnotator(func(err error, a []string) error {
+		assert.Fail(t, "this should not be called")
+		return err
+	}))
+
+	assert.NoError(t, err)
+}
+
+// Disabled named annotators pass errors through unchanged.
+func TestNamedAnnotator(t *testing.T) {
+	redact := cat.NamedAnnotator("redact", func(err error) error {
+		return fmt.Errorf("redacted error")
+	})
+	t.Cleanup(func() { cat.EnableAnnotator("redact") })
+
+	guard := func() error {
+		return cat.Guard(func(ct cat.Context) error {
+			ct.Catch(errTest, "password hunter2 rejected")
+			return nil
+		}, redact, "login failed")
+	}
+
+	assert.EqualError(t, guard(), "login failed: redacted error")
+
+	cat.DisableAnnotator("redact")
+	assert.EqualError(t, guard(), "login failed: password hunter2 rejected: test-error")
+
+	cat.EnableAnnotator("redact")
+	assert.EqualError(t, guard(), "login failed: redacted error")
+}
+
+// Conditional annotators only fire for matching errors.
+func TestWhenIs(t *testing.T) {
+	var handled []string
+	handler := func(name string) cat.Annotator {
+		return func(err error) error {
+			handled = append(handled, name)
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	guard := func(condition error) error {
+		return cat.Guard(func(ct cat.Context) error {
+			ct.Catch(condition, "problem")
+			return nil
+		},
+			cat.WhenIs(errTest, handler("test")),
+			cat.WhenIs(errTest2, handler("test2")),
+			cat.WhenAs(func(e *json.SyntaxError) error {
+				handled = append(handled, "syntax")
+				return nil
+			}),
+			handler("last"),
+		)
+	}
+
+	err := guard(errTest2)
+	assert.EqualError(t, err, "last: test2: problem: test-error2")
+	assert.Equal(t, []string{"test2", "last"}, handled)
+
+	// Returning nil from a matched annotator stops the chain.
+	handled = nil
+	err = guard(&json.SyntaxError{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"syntax"}, handled)
+}
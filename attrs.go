@@ -0,0 +1,44 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"errors"
+	"log/slog"
+)
+
+// Attaches structured logging attributes to an error in the chain.
+type attrsError struct {
+	err   error
+	attrs []slog.Attr
+}
+
+func (e *attrsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *attrsError) Unwrap() error {
+	return e.err
+}
+
+// Same as [Catch], but the propagated error carries the given attributes for structured
+// logging. They don't affect the error message, and they can be read with [AttrsOf] after
+// the error is recovered.
+//
+//	cat.CatchAttrs(err, []slog.Attr{slog.String("user", userID)}, "failed saving user")
+func CatchAttrs(condition any, attrs []slog.Attr, problem ...any) {
+	if err := caught(condition, problem); err != nil {
+		throw(&attrsError{err: err, attrs: attrs})
+	}
+}
+
+// Returns the attributes attached to the error by [CatchAttrs], or nil if there are none.
+// If more than one set of attributes is in the chain, the outermost set is returned.
+func AttrsOf(err error) []slog.Attr {
+	var ae *attrsError
+	if errors.As(err, &ae) {
+		return ae.attrs
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package errorcat_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// Attributes survive annotation and can be read after recovery.
+func TestCatchAttrs(t *testing.T) {
+	attrs := []slog.Attr{slog.String("user", "bob"), slog.Int("attempt", 3)}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchAttrs(errTest, attrs, "failed saving user")
+		return nil
+	}, "outer", errTest2)
+
+	assert.Equal(t, "test-error2: outer: failed saving user: test-error", err.Error())
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, attrs, cat.AttrsOf(err))
+
+	// Nothing happens if the condition doesn't trigger.
+	assert.NotPanics(t, func() {
+		cat.CatchAttrs(false, attrs, "problem")
+	})
+
+	// Errors without attributes return nil.
+	assert.Nil(t, cat.AttrsOf(errTest))
+}
@@ -0,0 +1,62 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"errors"
+	"time"
+)
+
+// Caught by [CatchSend] when the channel doesn't accept the value in time. This indicates
+// backpressure, i.e., the consumer is stalled or falling behind.
+var ErrSendTimeout = errors.New("channel send timed out")
+
+// Caught by [CatchSend] when the channel is closed.
+var ErrChannelClosed = errors.New("send on closed channel")
+
+/*
+Sends `v` to `ch`, catching [ErrSendTimeout] if the send doesn't complete within
+`timeout`. A zero or negative timeout tries the send once without blocking. This prevents
+a stalled consumer from silently deadlocking a producer; retry or load-shedding logic can
+check for ErrSendTimeout.
+
+If the channel is closed, [ErrChannelClosed] is caught rather than panicking.
+*/
+func CatchSend[T any](ch chan<- T, v T, timeout time.Duration, problem ...any) {
+	sent, closed := trySend(ch, v, timeout)
+	if closed {
+		Catch(ErrChannelClosed, problem...)
+	}
+	if !sent {
+		Catch(ErrSendTimeout, problem...)
+	}
+}
+
+// Sends to a channel with a timeout, recovering from a closed channel.
+func trySend[T any](ch chan<- T, v T, timeout time.Duration) (sent, closed bool) {
+	defer func() {
+		// The only thing that can panic here is sending on a closed channel.
+		if r := recover(); r != nil {
+			closed = true
+		}
+	}()
+
+	if timeout <= 0 {
+		select {
+		case ch <- v:
+			return true, false
+		default:
+			return false, false
+		}
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case ch <- v:
+		return true, false
+	case <-timer.C:
+		return false, false
+	}
+}
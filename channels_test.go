@@ -0,0 +1,63 @@
+package errorcat_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// Sends that complete in time succeed.
+func TestCatchSend(t *testing.T) {
+	ch := make(chan int, 1)
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchSend(ch, 1, 0)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, <-ch)
+
+	unbuffered := make(chan int)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		<-unbuffered
+	}()
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchSend(unbuffered, 2, time.Second)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+// Blocked sends time out.
+func TestCatchSendTimeout(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 1
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchSend(ch, 2, 0, "queue full")
+		return nil
+	})
+	assert.EqualError(t, err, "queue full: channel send timed out")
+	assert.ErrorIs(t, err, cat.ErrSendTimeout)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchSend(ch, 2, 10*time.Millisecond)
+		return nil
+	})
+	assert.ErrorIs(t, err, cat.ErrSendTimeout)
+}
+
+// Sends on a closed channel are caught with a distinct error.
+func TestCatchSendClosed(t *testing.T) {
+	ch := make(chan int, 1)
+	close(ch)
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchSend(ch, 1, time.Second, "queue closed")
+		return nil
+	})
+	assert.EqualError(t, err, "queue closed: send on closed channel")
+	assert.ErrorIs(t, err, cat.ErrChannelClosed)
+}
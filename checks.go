@@ -0,0 +1,156 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	gocontext "context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Catches if `err` doesn't wrap `expected` according to errors.Is. This is for defensive
+// boundaries between modules, to verify that an error from a dependency carries the
+// context you expect before acting on it. A nil `err` passes through, as there is
+// nothing to check.
+func CatchUnlessWrapped(err, expected error, problem ...any) {
+	if err == nil || errors.Is(err, expected) {
+		return
+	}
+	Catch(fmt.Errorf("error does not wrap %q: %w", expected, err), problem...)
+}
+
+/*
+Checks a precondition or invariant. Note that the condition is inverted from Catch:
+Assert catches when `cond` is false, so that it reads as the expected state:
+
+	cat.Assert(count > 0, "count must be positive")
+	// is the same as
+	cat.Catch(count <= 0, "count must be positive")
+
+If `problem` is not given, "assertion failed" is used.
+*/
+func Assert(cond bool, problem ...any) {
+	if cond {
+		return
+	}
+	if len(problem) == 0 {
+		problem = []any{"assertion failed"}
+	}
+	Catch(true, problem...)
+}
+
+// Returns the name of type T, e.g., "*fs.PathError".
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// Catches if no error in the chain of `err` is of type T, according to errors.As. This
+// enforces error-type contracts between layers, e.g., that a storage layer only returns
+// *StorageError. A nil `err` passes through, as there is nothing to check.
+func CatchErrorType[T error](err error, problem ...any) {
+	var target T
+	if err == nil || errors.As(err, &target) {
+		return
+	}
+	Catch(fmt.Errorf("error is not %s: %w", typeName[T](), err), problem...)
+}
+
+// The opposite of [CatchErrorType]. Catches if an error in the chain of `err` is of type
+// T, e.g., to verify that an internal error type doesn't leak past a module boundary.
+func CatchNotErrorType[T error](err error, problem ...any) {
+	var target T
+	if err != nil && errors.As(err, &target) {
+		Catch(fmt.Errorf("error is %s: %w", typeName[T](), err), problem...)
+	}
+}
+
+// Always catches, with an error naming the concrete type of `v`. This is meant for the
+// default case of a type switch that should be exhaustive:
+//
+//	switch n := node.(type) {
+//	case *NumberNode:
+//		...
+//	default:
+//		cat.CatchUnhandled(node, "unexpected node type")
+//	}
+//
+// The above produces "unexpected node type: got *FooNode". If `problem` is not given,
+// "unhandled type" is used.
+func CatchUnhandled(v any, problem ...any) {
+	if len(problem) == 0 {
+		problem = []any{"unhandled type"}
+	}
+	Catch(fmt.Errorf("got %T", v), problem...)
+}
+
+// Catches if the current time is after `deadline`. This is for checkpoints in a long,
+// self-contained computation that has a time budget. The propagated error wraps
+// context.DeadlineExceeded, so it can be distinguished from other failures with
+// errors.Is.
+func CatchDeadline(deadline time.Time, problem ...any) {
+	if now := time.Now(); now.After(deadline) {
+		err := fmt.Errorf("%w: %v past deadline", gocontext.DeadlineExceeded, now.Sub(deadline))
+		Catch(err, problem...)
+	}
+}
+
+// Catches the context's error if it's done, e.g., canceled or past its deadline, and
+// otherwise behaves like [Catch]. This adds cancellation checkpoints to long-running work
+// without repeating `if ctx.Err() != nil` checks. The error can be matched with
+// context.Canceled or context.DeadlineExceeded.
+//
+//	for _, item := range items {
+//		cat.CatchCtx(ctx, process(item), "processing failed")
+//	}
+func CatchCtx(ctx gocontext.Context, condition any, problem ...any) {
+	if err := ctx.Err(); err != nil {
+		Catch(err, problem...)
+	}
+	Catch(condition, problem...)
+}
+
+// Catches if `later` is not after `earlier`, e.g., for validating that the end of a range
+// is after its start. Equal times are caught, since they don't form a valid ordering.
+func CatchAfter2(later, earlier time.Time, problem ...any) {
+	if !later.After(earlier) {
+		Catch(fmt.Errorf("invalid time order: %s is not after %s",
+			later.Format(time.RFC3339Nano), earlier.Format(time.RFC3339Nano)), problem...)
+	}
+}
+
+// Catches if `earlier` is not before `later`. This is the inverse of [CatchAfter2]; equal
+// times are also caught.
+func CatchBefore(earlier, later time.Time, problem ...any) {
+	if !earlier.Before(later) {
+		Catch(fmt.Errorf("invalid time order: %s is not before %s",
+			earlier.Format(time.RFC3339Nano), later.Format(time.RFC3339Nano)), problem...)
+	}
+}
+
+// Catches if `t` is more than `window` away from the current time, in either direction.
+// This is for validating timestamps from clients, e.g., rejecting stale or future-dated
+// requests.
+func CatchWithin(t time.Time, window time.Duration, problem ...any) {
+	diff := time.Since(t)
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > window {
+		Catch(fmt.Errorf("time %s is not within %v of now", t.Format(time.RFC3339Nano), window),
+			problem...)
+	}
+}
+
+// Returns `s`, or catches if `validator` reports that it's not in the expected format. This
+// is for validating identifiers and other formatted strings from requests, e.g.:
+//
+//	id := cat.CatchFormat(req.ID, isValidSlug, "invalid post ID")
+func CatchFormat(s string, validator func(string) bool, problem ...any) string {
+	if !validator(s) {
+		Catch(fmt.Errorf("invalid format: %q", s), problem...)
+	}
+	return s
+}
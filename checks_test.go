@@ -0,0 +1,242 @@
+package errorcat_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// CatchUnlessWrapped catches errors that don't carry the expected error.
+func TestCatchUnlessWrapped(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchUnlessWrapped(fmt.Errorf("lookup: %w", errTest), errTest, "bad dependency error")
+		cat.CatchUnlessWrapped(nil, errTest, "bad dependency error")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchUnlessWrapped(errTest2, errTest, "bad dependency error")
+		return nil
+	})
+	assert.Equal(t, `bad dependency error: error does not wrap "test-error": test-error2`, err.Error())
+	assert.ErrorIs(t, err, errTest2)
+	assert.NotErrorIs(t, err, errTest)
+}
+
+type layerError struct{}
+
+func (e *layerError) Error() string { return "layer error" }
+
+// Error type checks look through wrap chains.
+func TestCatchErrorType(t *testing.T) {
+	wrapped := fmt.Errorf("query: %w", &layerError{})
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchErrorType[*layerError](wrapped, "bad storage error")
+		cat.CatchErrorType[*layerError](nil, "bad storage error")
+		cat.CatchNotErrorType[*layerError](errTest, "internal error leaked")
+		cat.CatchNotErrorType[*layerError](nil, "internal error leaked")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchErrorType[*layerError](errTest, "bad storage error")
+		return nil
+	})
+	assert.EqualError(t, err, "bad storage error: error is not *errorcat_test.layerError: test-error")
+	assert.ErrorIs(t, err, errTest)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchNotErrorType[*layerError](wrapped, "internal error leaked")
+		return nil
+	})
+	assert.EqualError(t, err, "internal error leaked: error is *errorcat_test.layerError: query: layer error")
+}
+
+type fooNode struct{}
+
+// CatchUnhandled names the concrete type in the message.
+func TestCatchUnhandled(t *testing.T) {
+	var node any = &fooNode{}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		switch node.(type) {
+		case string:
+		default:
+			cat.CatchUnhandled(node, "unexpected node type")
+		}
+		return nil
+	})
+	assert.Equal(t, "unexpected node type: got *errorcat_test.fooNode", err.Error())
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchUnhandled(123)
+		return nil
+	})
+	assert.Equal(t, "unhandled type: got int", err.Error())
+}
+
+// CatchDeadline catches once the deadline has passed.
+func TestCatchDeadline(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchDeadline(time.Now().Add(time.Hour), "over budget")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchDeadline(time.Now().Add(-time.Second), "over budget")
+		return nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Regexp(t, `^over budget: context deadline exceeded: .+ past deadline$`, err.Error())
+}
+
+// Time ordering checks catch invalid orderings, including equal times.
+func TestCatchTimeOrder(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchAfter2(end, start)
+		cat.CatchBefore(start, end)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchAfter2(start, end, "bad range")
+		return nil
+	})
+	assert.EqualError(t, err, "bad range: invalid time order: 2025-01-01T00:00:00Z is not after 2025-01-01T01:00:00Z")
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchAfter2(start, start)
+		return nil
+	})
+	assert.Error(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchBefore(end, start, "bad range")
+		return nil
+	})
+	assert.EqualError(t, err, "bad range: invalid time order: 2025-01-01T01:00:00Z is not before 2025-01-01T00:00:00Z")
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchBefore(start, start)
+		return nil
+	})
+	assert.Error(t, err)
+}
+
+// CatchWithin catches times too far from now in either direction.
+func TestCatchWithin(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchWithin(time.Now().Add(-time.Minute), time.Hour)
+		cat.CatchWithin(time.Now().Add(time.Minute), time.Hour)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchWithin(time.Now().Add(-2*time.Hour), time.Hour, "stale request")
+		return nil
+	})
+	assert.Regexp(t, `^stale request: time .+ is not within 1h0m0s of now$`, err.Error())
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchWithin(time.Now().Add(2*time.Hour), time.Hour)
+		return nil
+	})
+	assert.Error(t, err)
+}
+
+// CatchFormat returns valid strings and catches invalid ones.
+func TestCatchFormat(t *testing.T) {
+	isDigits := func(s string) bool {
+		for _, c := range s {
+			if c < '0' || c > '9' {
+				return false
+			}
+		}
+		return s != ""
+	}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		assert.Equal(t, "123", cat.CatchFormat("123", isDigits))
+		assert.Equal(t, "456", cat.CatchFormat("456", isDigits))
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchFormat("12a", isDigits, "invalid account number")
+		return nil
+	})
+	assert.EqualError(t, err, `invalid account number: invalid format: "12a"`)
+}
+
+// CatchCtx catches the context's error before the condition.
+func TestCatchCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchCtx(ctx, nil, "step failed")
+		cat.CatchCtx(ctx, false, "step failed")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchCtx(ctx, errTest, "step failed")
+		return nil
+	})
+	assert.EqualError(t, err, "step failed: test-error")
+
+	cancel()
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchCtx(ctx, nil, "step failed")
+		return nil
+	})
+	assert.EqualError(t, err, "step failed: context canceled")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchCtx(ctx, errTest, "step failed")
+		return nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, errTest)
+}
+
+// Assert catches when the condition is false.
+func TestAssert(t *testing.T) {
+	count := 0
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.Assert(count == 0, "count must be zero")
+		cat.Assert(count > 0, "count must be positive")
+		return nil
+	})
+	assert.EqualError(t, err, "count must be positive")
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.Assert(false)
+		return nil
+	})
+	assert.EqualError(t, err, "assertion failed")
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.Assert(false, errTest)
+		return nil
+	})
+	assert.Equal(t, errTest, err)
+}
@@ -0,0 +1,52 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+/*
+This package provides Errorcat helpers for verifying content against a checksum, e.g.,
+when downloading files.
+
+	resp, err := http.Get(url)
+	errorcat.Catch(err, "download failed")
+	defer resp.Body.Close()
+	data := checksumcat.CatchChecksum(resp.Body, expectedSHA256, "download failed")
+*/
+package checksumcat
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"go.mukunda.com/errorcat"
+)
+
+// Caught when the content doesn't match the expected checksum. Read errors are caught
+// without this tag.
+var ErrMismatch = errors.New("checksum mismatch")
+
+// Reads all of `r` and catches [ErrMismatch] if its SHA-256 checksum doesn't match
+// `expected`, a hex string. The content is returned on success. Read errors are caught
+// as well. `problem` works the same as in [errorcat.Catch].
+func CatchChecksum(r io.Reader, expected string, problem ...any) []byte {
+	return CatchChecksumWith(r, sha256.New, expected, problem...)
+}
+
+// Same as [CatchChecksum], but with a different hash algorithm, e.g., sha512.New.
+func CatchChecksumWith(r io.Reader, newHash func() hash.Hash, expected string, problem ...any) []byte {
+	h := newHash()
+	data, err := io.ReadAll(io.TeeReader(r, h))
+	if err != nil {
+		errorcat.Catch(fmt.Errorf("reading content: %w", err), problem...)
+	}
+
+	actual := hex.EncodeToString(h.Sum(nil))
+	if !strings.EqualFold(actual, strings.TrimSpace(expected)) {
+		errorcat.Catch(fmt.Errorf("%w: expected %s, got %s", ErrMismatch, expected, actual),
+			problem...)
+	}
+	return data
+}
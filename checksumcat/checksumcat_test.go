@@ -0,0 +1,56 @@
+package checksumcat_test
+
+import (
+	"crypto/sha512"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"go.mukunda.com/errorcat"
+	"go.mukunda.com/errorcat/checksumcat"
+)
+
+const helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+// Matching content is returned.
+func TestCatchChecksumMatch(t *testing.T) {
+	var data []byte
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		data = checksumcat.CatchChecksum(strings.NewReader("hello"), strings.ToUpper(helloSHA256))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	err = errorcat.Guard(func(ct errorcat.Context) error {
+		checksumcat.CatchChecksumWith(strings.NewReader("hello"), sha512.New,
+			"9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca7"+
+				"2323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043")
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+// Mismatched content is caught with both checksums.
+func TestCatchChecksumMismatch(t *testing.T) {
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		checksumcat.CatchChecksum(strings.NewReader("hello"), "abcd", "download failed")
+		return nil
+	})
+	assert.ErrorIs(t, err, checksumcat.ErrMismatch)
+	assert.EqualError(t, err, "download failed: checksum mismatch: expected abcd, got "+helloSHA256)
+}
+
+// Read errors are caught without the mismatch tag.
+func TestCatchChecksumReadError(t *testing.T) {
+	errRead := errors.New("connection reset")
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		checksumcat.CatchChecksum(iotest.ErrReader(errRead), helloSHA256, "download failed")
+		return nil
+	})
+	assert.ErrorIs(t, err, errRead)
+	assert.NotErrorIs(t, err, checksumcat.ErrMismatch)
+	assert.EqualError(t, err, "download failed: reading content: connection reset")
+}
@@ -0,0 +1,82 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Returns s[i], or catches a descriptive error if `i` is out of bounds, e.g., "index 5
+// out of range [0,3)". This replaces the runtime's index panic with an error that carries
+// context.
+func CatchIndex[T any](s []T, i int, problem ...any) T {
+	if i < 0 || i >= len(s) {
+		Catch(fmt.Errorf("index %d out of range [0,%d)", i, len(s)), problem...)
+	}
+	return s[i]
+}
+
+// Catches if len(s) is not `want`, with a message like "invalid length: got 2, want 3".
+// This is useful when parsing fixed-format records.
+func CatchLen[T any](s []T, want int, problem ...any) {
+	catchLen(len(s), want, want, problem)
+}
+
+// Catches if len(s) is outside of [min, max], with a message like "invalid length: got 5,
+// want between 1 and 3".
+func CatchLenRange[T any](s []T, min, max int, problem ...any) {
+	catchLen(len(s), min, max, problem)
+}
+
+// Same as [CatchLen], but for the length of a string in bytes.
+func CatchStrLen(s string, want int, problem ...any) {
+	catchLen(len(s), want, want, problem)
+}
+
+func catchLen(n, min, max int, problem []any) {
+	if n >= min && n <= max {
+		return
+	}
+	if min == max {
+		Catch(fmt.Errorf("invalid length: got %d, want %d", n, min), problem...)
+	}
+	Catch(fmt.Errorf("invalid length: got %d, want between %d and %d", n, min, max), problem...)
+}
+
+// Catches if `s` contains a duplicate value, naming the first value that repeats, e.g.,
+// "duplicate value "b" at index 3". This is for validating unique keys or IDs in input.
+// Detection uses a map, so it's O(n) in time and memory.
+func CatchDuplicates[T comparable](s []T, problem ...any) {
+	seen := make(map[T]struct{}, len(s))
+	for i, v := range s {
+		if _, ok := seen[v]; ok {
+			Catch(fmt.Errorf("duplicate value %#v at index %d", v, i), problem...)
+		}
+		seen[v] = struct{}{}
+	}
+}
+
+// Catches if `v` is not in `allowed`, listing the allowed values, e.g., `invalid value
+// "purple": must be one of "red", "green", "blue"`. Otherwise, `v` is returned. This is
+// for validating enum-like inputs from APIs and configuration. An empty allowed set always
+// catches.
+func CatchOneOf[T comparable](v T, allowed []T, problem ...any) T {
+	for _, a := range allowed {
+		if v == a {
+			return v
+		}
+	}
+
+	if len(allowed) == 0 {
+		Catch(fmt.Errorf("invalid value %#v: no values are allowed", v), problem...)
+	}
+	names := make([]string, len(allowed))
+	for i, a := range allowed {
+		names[i] = fmt.Sprintf("%#v", a)
+	}
+	Catch(fmt.Errorf("invalid value %#v: must be one of %s", v, strings.Join(names, ", ")),
+		problem...)
+	return v
+}
@@ -0,0 +1,117 @@
+package errorcat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// CatchIndex returns the element or catches an error with the index and length.
+func TestCatchIndex(t *testing.T) {
+	s := []string{"a", "b", "c"}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		assert.Equal(t, "a", cat.CatchIndex(s, 0))
+		assert.Equal(t, "c", cat.CatchIndex(s, 2))
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchIndex(s, 3, "bad column")
+		return nil
+	})
+	assert.EqualError(t, err, "bad column: index 3 out of range [0,3)")
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchIndex(s, -1)
+		return nil
+	})
+	assert.EqualError(t, err, "index -1 out of range [0,3)")
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchIndex([]int{}, 0)
+		return nil
+	})
+	assert.EqualError(t, err, "index 0 out of range [0,0)")
+}
+
+// Length checks catch when the length is wrong.
+func TestCatchLen(t *testing.T) {
+	record := []string{"a", "b", "c"}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchLen(record, 3)
+		cat.CatchLenRange(record, 1, 3)
+		cat.CatchLenRange(record, 3, 5)
+		cat.CatchStrLen("abc", 3)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		fn      func(ct cat.Context)
+		message string
+	}{
+		{func(ct cat.Context) { cat.CatchLen(record, 4, "bad record") }, "bad record: invalid length: got 3, want 4"},
+		{func(ct cat.Context) { cat.CatchLen(record, 2) }, "invalid length: got 3, want 2"},
+		{func(ct cat.Context) { cat.CatchLenRange(record, 4, 6) }, "invalid length: got 3, want between 4 and 6"},
+		{func(ct cat.Context) { cat.CatchLenRange(record, 0, 2) }, "invalid length: got 3, want between 0 and 2"},
+		{func(ct cat.Context) { cat.CatchStrLen("abcd", 3, "bad code") }, "bad code: invalid length: got 4, want 3"},
+		{func(ct cat.Context) { cat.CatchStrLen("ab", 3) }, "invalid length: got 2, want 3"},
+	} {
+		err := cat.Guard(func(ct cat.Context) error {
+			tc.fn(ct)
+			return nil
+		})
+		assert.EqualError(t, err, tc.message)
+	}
+}
+
+// CatchDuplicates names the first repeated value.
+func TestCatchDuplicates(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchDuplicates([]string{"a", "b", "c"}, "ids must be unique")
+		cat.CatchDuplicates([]int{}, "ids must be unique")
+		cat.CatchDuplicates[int](nil, "ids must be unique")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchDuplicates([]string{"a", "b", "c", "c", "b"}, "ids must be unique")
+		return nil
+	})
+	assert.EqualError(t, err, `ids must be unique: duplicate value "c" at index 3`)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchDuplicates([]int{1, 2, 1})
+		return nil
+	})
+	assert.EqualError(t, err, "duplicate value 1 at index 2")
+}
+
+// CatchOneOf lists the allowed values.
+func TestCatchOneOf(t *testing.T) {
+	colors := []string{"red", "green", "blue"}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		assert.Equal(t, "green", cat.CatchOneOf("green", colors))
+		assert.Equal(t, 2, cat.CatchOneOf(2, []int{1, 2, 3}))
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchOneOf("purple", colors, "bad color")
+		return nil
+	})
+	assert.EqualError(t, err, `bad color: invalid value "purple": must be one of "red", "green", "blue"`)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchOneOf(1, nil)
+		return nil
+	})
+	assert.EqualError(t, err, "invalid value 1: no values are allowed")
+}
@@ -3,7 +3,12 @@
 
 package errorcat
 
-import "runtime"
+import (
+	gocontext "context"
+	"fmt"
+	"runtime"
+	"time"
+)
 
 /*
 For library code, you need to ensure that you aren't passing panics past your package
@@ -27,10 +32,129 @@ type Context interface {
 	ErrorRef() *error
 }
 
+/*
+Extends [Context] with wrappers for the other catch functions and with per-guard state such
+as panic callbacks, compensations and values. It's kept separate so that [Context] stays
+small for other implementations. The contexts created by errorcat implement it, so a
+function that needs the extra methods can assert for them:
+
+	ext := ct.(cat.ExtendedContext)
+	ext.Compensate(func() error { return releaseInventory(order) })
+*/
+type ExtendedContext interface {
+	Context
+
+	// Wrapper for CatchUnlessWrapped.
+	CatchUnlessWrapped(err, expected error, problem ...any)
+
+	// Wrapper for CatchUnhandled.
+	CatchUnhandled(v any, problem ...any)
+
+	// Wrapper for CatchDeadline.
+	CatchDeadline(deadline time.Time, problem ...any)
+
+	// Wrapper for CatchAfter2.
+	CatchAfter2(later, earlier time.Time, problem ...any)
+
+	// Wrapper for CatchBefore.
+	CatchBefore(earlier, later time.Time, problem ...any)
+
+	// Wrapper for CatchWithin.
+	CatchWithin(t time.Time, window time.Duration, problem ...any)
+
+	// Wrapper for CatchFormat.
+	CatchFormat(s string, validator func(string) bool, problem ...any) string
+
+	// Wrapper for CatchRatio.
+	CatchRatio(v float64, problem ...any) float64
+
+	// Wrapper for CatchField.
+	CatchField(m map[string]any, key string, problem ...any) any
+
+	// Wrapper for CatchStringField.
+	CatchStringField(m map[string]any, key string, problem ...any) string
+
+	// Wrapper for CatchIntField.
+	CatchIntField(m map[string]any, key string, problem ...any) int
+
+	// Wrapper for CatchStrLen.
+	CatchStrLen(s string, want int, problem ...any)
+
+	// Wrapper for CatchAllOf.
+	CatchAllOf(problem string, checks ...func() error)
+
+	// Wrapper for CatchDuplicateRequest.
+	CatchDuplicateRequest(store IdempotencyStore, key string, problem ...any)
+
+	// Wrapper for CatchFinite.
+	CatchFinite(v float64, problem ...any) float64
+
+	// Wrapper for CatchAllFinite.
+	CatchAllFinite(vs []float64, problem ...any) []float64
+
+	// Wrapper for CatchZeroField.
+	CatchZeroField(v any, fieldName string, problem ...any)
+
+	// Wrapper for CatchRequiredFields.
+	CatchRequiredFields(v any, names ...string)
+
+	// Wrapper for Catchf.
+	Catchf(condition any, format string, args ...any)
+
+	// Wrapper for CatchCtx.
+	CatchCtx(ctx gocontext.Context, condition any, problem ...any)
+
+	// Wrapper for Assert.
+	Assert(cond bool, problem ...any)
+
+	// Wrapper for CatchAny.
+	CatchAny(condition any, problem ...any)
+
+	// Wrapper for CatchAll.
+	CatchAll(conditions ...error)
+
+	// Wrapper for CatchAllf.
+	CatchAllf(conditions []error, format string, args ...any)
+
+	// Wrapper for CatchJoin.
+	CatchJoin(condition error, problems ...error)
+
+	// Registers a callback to be called by Recover with the raw panic value, before it's
+	// processed into an error or annotated. Callbacks run in registration order.
+	OnPanic(fn func(recovered any))
+
+	// Registers a compensating action to be run by Recover if the guard fails.
+	// Compensations run in reverse registration order.
+	Compensate(fn func() error)
+
+	// Sets a prefix for errors from Catch that are recovered by the context's Recover.
+	SetPrefix(prefix string)
+
+	// Records a warning instead of propagating an error. Returns true if the condition
+	// triggered.
+	Warn(condition any, problem ...any) bool
+
+	// Creates a child context for a sub-operation whose error is merged into this context
+	// by the returned finalize function.
+	Sub(name string) (ExtendedContext, func())
+
+	// Returns a derived context that carries a value for `key`, e.g., a request ID. It
+	// shares the guard of this context, so it can be used interchangeably with it.
+	WithValue(key, val any) ExtendedContext
+
+	// Returns the value for `key` set with WithValue, or nil if there is none.
+	Value(key any) any
+}
+
 // Default context implementation.
 type context struct {
 	errorRef      *error
 	recoverCalled bool
+	panicHandlers []func(recovered any)
+	compensations []func() error
+	warnings      []error
+	subErrors     []error
+	prefix        string
 }
 
 // A callback function issued when [Recover] is called.
@@ -60,18 +184,342 @@ func NewContext(errorRef *error) Context {
 	return ct
 }
 
-// Context-based wrapper for [Catch].
-func (c *context) Catch(condition any, problem ...any) {
+// Implemented by contexts that are backed by the default implementation, including
+// derived ones. Recover reads their state through this, so it doesn't need to be part of
+// [Context].
+type contextBase interface {
+	base() *context
+}
+
+func (c *context) base() *context {
+	return c
+}
+
+// Returns the default implementation behind a context, or nil if it's another
+// implementation of [Context].
+func baseContext(ct Context) *context {
+	if b, ok := ct.(contextBase); ok {
+		return b.base()
+	}
+	return nil
+}
+
+// Panics if the context is no longer guarded.
+func (c *context) checkGuarded() {
 	if c.recoverCalled {
 		// The user likely forgot to defer the recover. Additional catch calls should not be
 		// made with the context after Recover is called.
 		panic("[errorcat] Catch was called after recovery.")
 	}
+}
+
+// Context-based wrapper for [Catch].
+func (c *context) Catch(condition any, problem ...any) {
+	c.checkGuarded()
 	Catch(condition, problem...)
 }
 
+// Context-based wrapper for [CatchUnlessWrapped].
+func (c *context) CatchUnlessWrapped(err, expected error, problem ...any) {
+	c.checkGuarded()
+	CatchUnlessWrapped(err, expected, problem...)
+}
+
 // Returns a reference to the top-level error that was captured when creating this
 // context. This can be nil.
 func (c *context) ErrorRef() *error {
 	return c.errorRef
 }
+
+// Context-based wrapper for [CatchUnhandled].
+func (c *context) CatchUnhandled(v any, problem ...any) {
+	c.checkGuarded()
+	CatchUnhandled(v, problem...)
+}
+
+// Context-based wrapper for [CatchDeadline].
+func (c *context) CatchDeadline(deadline time.Time, problem ...any) {
+	c.checkGuarded()
+	CatchDeadline(deadline, problem...)
+}
+
+// Context-based wrapper for [CatchAfter2].
+func (c *context) CatchAfter2(later, earlier time.Time, problem ...any) {
+	c.checkGuarded()
+	CatchAfter2(later, earlier, problem...)
+}
+
+// Context-based wrapper for [CatchBefore].
+func (c *context) CatchBefore(earlier, later time.Time, problem ...any) {
+	c.checkGuarded()
+	CatchBefore(earlier, later, problem...)
+}
+
+// Context-based wrapper for [CatchWithin].
+func (c *context) CatchWithin(t time.Time, window time.Duration, problem ...any) {
+	c.checkGuarded()
+	CatchWithin(t, window, problem...)
+}
+
+// Context-based wrapper for [CatchFormat].
+func (c *context) CatchFormat(s string, validator func(string) bool, problem ...any) string {
+	c.checkGuarded()
+	return CatchFormat(s, validator, problem...)
+}
+
+// Context-based wrapper for [CatchRatio].
+func (c *context) CatchRatio(v float64, problem ...any) float64 {
+	c.checkGuarded()
+	return CatchRatio(v, problem...)
+}
+
+// Context-based wrapper for [CatchField].
+func (c *context) CatchField(m map[string]any, key string, problem ...any) any {
+	c.checkGuarded()
+	return CatchField(m, key, problem...)
+}
+
+// Context-based wrapper for [CatchStringField].
+func (c *context) CatchStringField(m map[string]any, key string, problem ...any) string {
+	c.checkGuarded()
+	return CatchStringField(m, key, problem...)
+}
+
+// Context-based wrapper for [CatchIntField].
+func (c *context) CatchIntField(m map[string]any, key string, problem ...any) int {
+	c.checkGuarded()
+	return CatchIntField(m, key, problem...)
+}
+
+// Context-based wrapper for [CatchStrLen].
+func (c *context) CatchStrLen(s string, want int, problem ...any) {
+	c.checkGuarded()
+	CatchStrLen(s, want, problem...)
+}
+
+// Context-based wrapper for [CatchAllOf].
+func (c *context) CatchAllOf(problem string, checks ...func() error) {
+	c.checkGuarded()
+	CatchAllOf(problem, checks...)
+}
+
+// Context-based wrapper for [CatchDuplicateRequest].
+func (c *context) CatchDuplicateRequest(store IdempotencyStore, key string, problem ...any) {
+	c.checkGuarded()
+	CatchDuplicateRequest(store, key, problem...)
+}
+
+// Context-based wrapper for [CatchFinite].
+func (c *context) CatchFinite(v float64, problem ...any) float64 {
+	c.checkGuarded()
+	return CatchFinite(v, problem...)
+}
+
+// Context-based wrapper for [CatchAllFinite].
+func (c *context) CatchAllFinite(vs []float64, problem ...any) []float64 {
+	c.checkGuarded()
+	return CatchAllFinite(vs, problem...)
+}
+
+// Context-based wrapper for [CatchZeroField].
+func (c *context) CatchZeroField(v any, fieldName string, problem ...any) {
+	c.checkGuarded()
+	CatchZeroField(v, fieldName, problem...)
+}
+
+// Context-based wrapper for [CatchRequiredFields].
+func (c *context) CatchRequiredFields(v any, names ...string) {
+	c.checkGuarded()
+	CatchRequiredFields(v, names...)
+}
+
+// Context-based wrapper for [Catchf].
+func (c *context) Catchf(condition any, format string, args ...any) {
+	c.checkGuarded()
+	Catchf(condition, format, args...)
+}
+
+// Context-based wrapper for [CatchCtx].
+func (c *context) CatchCtx(ctx gocontext.Context, condition any, problem ...any) {
+	c.checkGuarded()
+	CatchCtx(ctx, condition, problem...)
+}
+
+// Context-based wrapper for [Assert].
+func (c *context) Assert(cond bool, problem ...any) {
+	c.checkGuarded()
+	Assert(cond, problem...)
+}
+
+// Context-based wrapper for [CatchAny].
+func (c *context) CatchAny(condition any, problem ...any) {
+	c.checkGuarded()
+	CatchAny(condition, problem...)
+}
+
+// Context-based wrapper for [CatchAll].
+func (c *context) CatchAll(conditions ...error) {
+	c.checkGuarded()
+	CatchAll(conditions...)
+}
+
+// Context-based wrapper for [CatchAllf].
+func (c *context) CatchAllf(conditions []error, format string, args ...any) {
+	c.checkGuarded()
+	CatchAllf(conditions, format, args...)
+}
+
+// Context-based wrapper for [CatchJoin].
+func (c *context) CatchJoin(condition error, problems ...error) {
+	c.checkGuarded()
+	CatchJoin(condition, problems...)
+}
+
+/*
+Registers a callback to be called by [Recover] with the raw panic value. This runs during
+recovery, before annotators, so it can capture diagnostics at the moment of failure, e.g.,
+dumping local state or taking a heap profile:
+
+	ct.OnPanic(func(recovered any) {
+		log.Printf("failed while processing item %d: %v", index, recovered)
+	})
+
+Callbacks are not called if the guard exits without panicking.
+*/
+func (c *context) OnPanic(fn func(recovered any)) {
+	c.checkGuarded()
+	c.panicHandlers = append(c.panicHandlers, fn)
+}
+
+/*
+Registers a compensating action that undoes a completed step. If the guard fails, [Recover]
+runs the compensations in reverse order (last registered runs first) before the
+annotators. Errors from compensations are joined into the final error. This implements
+the saga pattern within a guard:
+
+	reserveInventory(ct, order)
+	ct.Compensate(func() error { return releaseInventory(order) })
+
+	chargePayment(ct, order)
+	ct.Compensate(func() error { return refundPayment(order) })
+
+	shipOrder(ct, order) // If this fails, the payment is refunded, then inventory released.
+
+Compensations are not run if the guard succeeds.
+*/
+func (c *context) Compensate(fn func() error) {
+	c.checkGuarded()
+	c.compensations = append(c.compensations, fn)
+}
+
+/*
+Sets a prefix for errors from Catch that are recovered by the context's [Recover],
+including those from the package-level Catch in subfunctions. Calling it again replaces
+the prefix. This avoids repeating identifiers in every Catch call:
+
+	ct.SetPrefix("request " + requestID)
+	ct.Catch(err, "failed loading user") // "request 123: failed loading user: ..."
+
+The prefix is applied once, when the error is recovered, so errors that pass through
+nested guards aren't prefixed again when they're caught a second time.
+*/
+func (c *context) SetPrefix(prefix string) {
+	c.checkGuarded()
+	c.prefix = prefix
+}
+
+// Records a warning if the condition triggers, and returns true in that case. The warning
+// is formed the same way as the error from [Catch], but it doesn't interrupt execution.
+// Warnings can be read with Warnings, or returned by [GuardWarn]. They are also passed to
+// the handler set with [SetWarnHandler].
+func (c *context) Warn(condition any, problem ...any) bool {
+	c.checkGuarded()
+	err := formError(condition, problem)
+	if err == nil {
+		return false
+	}
+	c.warnings = append(c.warnings, err)
+	handleWarning(err)
+	return true
+}
+
+/*
+Creates a child context for a sub-operation. The returned finalize function must be
+deferred; it recovers the child and, if the child caught an error, merges it into this
+context prefixed with `name`. The parent continues running, and [Recover] joins the merged
+errors into the parent's final error. This groups the failures of independent steps
+within one guard:
+
+	for _, file := range files {
+		func() {
+			sub, finalize := ct.Sub(file)
+			defer finalize()
+			processFile(sub, file)
+		}()
+	}
+
+Compensations and OnPanic callbacks registered on the child run when it's finalized. Fatal
+errors are not merged; they escape like in any other guard.
+*/
+func (c *context) Sub(name string) (ExtendedContext, func()) {
+	c.checkGuarded()
+	var err error
+	child := NewContext(&err)
+	return child.(*context), func() {
+		handleRecover(child, &err, recover(), panicPassthrough.Load(), nil)
+		if err != nil {
+			c.subErrors = append(c.subErrors, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+}
+
+// A context derived with WithValue. It shares the state of the original context, so
+// Recover on either one finalizes both.
+type valueContext struct {
+	*context
+	values map[any]any
+}
+
+/*
+Returns a derived context that carries a value for `key`. This is for request-scoped
+metadata that annotators can read to enrich errors:
+
+	ct := cat.NewContext(&rerr).(cat.ExtendedContext).WithValue(requestIDKey{}, id)
+	defer cat.Recover(ct, func(err error) error {
+		return fmt.Errorf("request %v failed: %w", ct.Value(requestIDKey{}), err)
+	})
+
+Like with context.WithValue, keys must be comparable and should be of an unexported type
+to avoid collisions. Child contexts created with Sub inherit the values.
+*/
+func (c *context) WithValue(key, val any) ExtendedContext {
+	c.checkGuarded()
+	return &valueContext{context: c, values: map[any]any{key: val}}
+}
+
+// Returns nil, as the context has no values. See WithValue.
+func (c *context) Value(key any) any {
+	return nil
+}
+
+// Returns a derived context with `key` added to the values.
+func (v *valueContext) WithValue(key, val any) ExtendedContext {
+	v.checkGuarded()
+	values := make(map[any]any, len(v.values)+1)
+	for k, val := range v.values {
+		values[k] = val
+	}
+	values[key] = val
+	return &valueContext{context: v.context, values: values}
+}
+
+// Returns the value for `key`, or nil if there is none.
+func (v *valueContext) Value(key any) any {
+	return v.values[key]
+}
+
+// Same as the Sub method of the original context, but the child inherits the values.
+func (v *valueContext) Sub(name string) (ExtendedContext, func()) {
+	child, finalize := v.context.Sub(name)
+	return &valueContext{context: child.(*context), values: v.values}, finalize
+}
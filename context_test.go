@@ -1,6 +1,8 @@
 package errorcat_test
 
 import (
+	"errors"
+	"fmt"
 	"runtime"
 	"testing"
 
@@ -51,3 +53,225 @@ func TestForgotDefer(t *testing.T) {
 	}()
 
 }
+
+// Contexts from errorcat implement ExtendedContext, and its wrappers are guarded like Catch.
+func TestExtendedContext(t *testing.T) {
+	ct := cat.NewContext(nil)
+	ext, ok := ct.(cat.ExtendedContext)
+	assert.True(t, ok)
+
+	derived := ext.WithValue(requestIDKey{}, 1)
+	sub, done := derived.Sub("sub")
+	done()
+	cat.Recover(ct)
+
+	assert.PanicsWithValue(t, "[errorcat] Catch was called after recovery.", func() {
+		ext.CatchAny(true, "whoops")
+	})
+	assert.PanicsWithValue(t, "[errorcat] Catch was called after recovery.", func() {
+		sub.CatchAny(true, "whoops")
+	})
+}
+
+// OnPanic callbacks receive the raw panic value before it's processed.
+func TestOnPanic(t *testing.T) {
+	var steps []string
+	var recovered []any
+
+	err := cat.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		ext.OnPanic(func(r any) {
+			steps = append(steps, "first")
+			recovered = append(recovered, r)
+		})
+		ext.OnPanic(func(r any) {
+			steps = append(steps, "second")
+			recovered = append(recovered, r)
+		})
+		panic("raw value")
+	}, func(err error) error {
+		steps = append(steps, "annotator")
+		return err
+	})
+
+	assert.Equal(t, "raw value", err.Error())
+	assert.Equal(t, []string{"first", "second", "annotator"}, steps)
+	assert.Equal(t, []any{"raw value", "raw value"}, recovered)
+
+	// Errors from Catch are seen as their CatError.
+	err = cat.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		ext.OnPanic(func(r any) {
+			recovered = []any{r}
+		})
+		ct.Catch(errTest)
+		return nil
+	})
+
+	assert.Equal(t, errTest, err)
+	assert.IsType(t, cat.CatError{}, recovered[0])
+
+	// Callbacks are not called on success or for returned errors.
+	err = cat.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		ext.OnPanic(func(r any) {
+			assert.Fail(t, "this should not be called")
+		})
+		return errTest
+	})
+
+	assert.Equal(t, errTest, err)
+}
+
+// Compensations run in reverse order when a later step fails.
+func TestCompensate(t *testing.T) {
+	var steps []string
+	errRollback := errors.New("rollback failed")
+
+	step := func(ct cat.Context, name string, fail bool, compensation error) {
+		ct.Catch(fail, name+" failed")
+		steps = append(steps, name)
+		ct.(cat.ExtendedContext).Compensate(func() error {
+			steps = append(steps, "undo "+name)
+			return compensation
+		})
+	}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		step(ct, "step1", false, nil)
+		step(ct, "step2", false, errRollback)
+		step(ct, "step3", true, nil)
+		return nil
+	}, "saga")
+
+	assert.Equal(t, []string{"step1", "step2", "undo step2", "undo step1"}, steps)
+	assert.Equal(t, "saga: step3 failed\nrollback failed", err.Error())
+	assert.ErrorIs(t, err, errRollback)
+
+	// Successful compensations leave the error untouched.
+	steps = nil
+	err = cat.Guard(func(ct cat.Context) error {
+		step(ct, "step1", false, nil)
+		ct.Catch(errTest)
+		return nil
+	})
+
+	assert.Equal(t, []string{"step1", "undo step1"}, steps)
+	assert.Equal(t, errTest, err)
+
+	// Nothing is compensated on success.
+	steps = nil
+	err = cat.Guard(func(ct cat.Context) error {
+		step(ct, "step1", false, nil)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"step1"}, steps)
+}
+
+// GuardWarn returns warnings on the success path.
+func TestGuardWarn(t *testing.T) {
+	err, warnings := cat.GuardWarn(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		assert.False(t, ext.Warn(nil, "not a warning"))
+		assert.False(t, ext.Warn(false, "not a warning"))
+		assert.True(t, ext.Warn(errTest, "skipped record 3"))
+		assert.True(t, ext.Warn(true, "skipped record 5"))
+		return nil
+	}, "import failed")
+
+	assert.NoError(t, err)
+	if assert.Len(t, warnings, 2) {
+		assert.EqualError(t, warnings[0], "skipped record 3: test-error")
+		assert.ErrorIs(t, warnings[0], errTest)
+		assert.EqualError(t, warnings[1], "skipped record 5")
+	}
+
+	// Warnings are also returned when the guard fails.
+	err, warnings = cat.GuardWarn(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		ext.Warn(true, "skipped record 1")
+		ct.Catch(errTest2, "database failed")
+		return nil
+	}, "import failed")
+
+	assert.EqualError(t, err, "import failed: database failed: test-error2")
+	assert.Len(t, warnings, 1)
+}
+
+// Errors from sub-operations are merged into the parent's error, prefixed with their name.
+func TestContextSub(t *testing.T) {
+	var reached bool
+	err := cat.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+			func() {
+				sub, finalize := ext.Sub(name)
+				defer finalize()
+				sub.Catch(name == "b.txt", "file is corrupt")
+				sub.Catch(name == "c.txt", errTest)
+			}()
+		}
+		reached = true
+		return nil
+	}, "import failed")
+
+	assert.True(t, reached)
+	assert.EqualError(t, err, "import failed: b.txt: file is corrupt\nc.txt: test-error")
+	assert.ErrorIs(t, err, errTest)
+
+	// Successful sub-operations don't affect the parent.
+	err = cat.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		sub, finalize := ext.Sub("ok")
+		defer finalize()
+		sub.Catch(false, "not triggered")
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+type requestIDKey struct{}
+type userKey struct{}
+
+// Values set with WithValue are visible to annotators and nested calls.
+func TestContextValues(t *testing.T) {
+	step := func(ct cat.Context) {
+		ext := ct.(cat.ExtendedContext)
+		assert.Equal(t, 123, ext.Value(requestIDKey{}))
+		ct.Catch(errTest, "step failed")
+	}
+
+	handle := func(id int) (rerr error) {
+		ct := cat.NewContext(&rerr).(cat.ExtendedContext).WithValue(requestIDKey{}, id)
+		defer cat.Recover(ct, func(err error) error {
+			return fmt.Errorf("request %v failed: %w", ct.Value(requestIDKey{}), err)
+		})
+		func() {
+			step(ct)
+		}()
+		return nil
+	}
+	assert.EqualError(t, handle(123), "request 123 failed: step failed: test-error")
+
+	err := cat.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		assert.Nil(t, ext.Value(requestIDKey{}))
+
+		ct1 := ext.WithValue(requestIDKey{}, 1)
+		ct2 := ct1.WithValue(userKey{}, "alice")
+		assert.Equal(t, 1, ct2.Value(requestIDKey{}))
+		assert.Equal(t, "alice", ct2.Value(userKey{}))
+		assert.Nil(t, ct1.Value(userKey{}))
+		assert.Nil(t, ct2.Value("missing"))
+
+		sub, done := ct2.Sub("sub")
+		assert.Equal(t, "alice", sub.Value(userKey{}))
+		done()
+
+		ct2.Catch(errTest)
+		return nil
+	})
+	assert.Equal(t, errTest, err)
+}
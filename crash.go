@@ -0,0 +1,95 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+/*
+Same as [Guard], but writes a crash report if `fn` panics unexpectedly, i.e., with a real
+panic rather than an error propagated by Catch. This is for the top-level guard of desktop
+and CLI apps:
+
+	func main() {
+		err := cat.GuardCrashReport(crashDir, run)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+The report is written to `dir/crash-<timestamp>.txt` with the error, the panic value, the
+stack of the panic, and information about the program's environment. The returned error
+points the user to the report and wraps the original error. Caught errors are returned
+normally without a report.
+*/
+func GuardCrashReport(dir string, fn GuardFunc, annotate ...any) error {
+	var crashed bool
+	var recovered any
+
+	err := guard(func(ct Context) error {
+		ct.(ExtendedContext).OnPanic(func(r any) {
+			if _, ok := r.(CatError); !ok {
+				crashed, recovered = true, r
+			}
+		})
+		return fn(ct)
+	}, annotate)
+
+	if !crashed {
+		return err
+	}
+
+	// Recover captured the stack of the panic, which includes the panicking frame.
+	stack := "(not available)\n"
+	var pe *PanicError
+	if errors.As(err, &pe) {
+		stack = pe.Stack()
+	}
+
+	path, werr := writeCrashReport(dir, err, recovered, stack)
+	if werr != nil {
+		return fmt.Errorf("the program crashed, and the crash report couldn't be written (%v): %w",
+			werr, err)
+	}
+	return fmt.Errorf("the program crashed; a crash report was written to %s: %w", path, err)
+}
+
+// Writes a crash report for GuardCrashReport and returns its path.
+func writeCrashReport(dir string, err error, recovered any, stack string) (string, error) {
+	now := time.Now()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Crash report\n\n")
+	fmt.Fprintf(&sb, "Time:    %s\n", now.Format(time.RFC3339Nano))
+	fmt.Fprintf(&sb, "Error:   %v\n", err)
+	fmt.Fprintf(&sb, "Panic:   %v (%T)\n\n", recovered, recovered)
+
+	fmt.Fprintf(&sb, "Environment\n\n")
+	fmt.Fprintf(&sb, "Go:      %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&sb, "Command: %q\n", os.Args)
+	fmt.Fprintf(&sb, "PID:     %d\n", os.Getpid())
+	if info, ok := debug.ReadBuildInfo(); ok {
+		fmt.Fprintf(&sb, "Module:  %s %s\n", info.Main.Path, info.Main.Version)
+	}
+
+	fmt.Fprintf(&sb, "\nStack\n\n%s", stack)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "crash-"+now.Format("20060102-150405.000000")+".txt")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
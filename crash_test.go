@@ -0,0 +1,49 @@
+package errorcat_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+func crashingFunction() {
+	var m map[string]int
+	m["boom"] = 1
+}
+
+// Real panics produce a crash report.
+func TestGuardCrashReport(t *testing.T) {
+	dir := t.TempDir()
+
+	err := cat.GuardCrashReport(dir, func(ct cat.Context) error {
+		crashingFunction()
+		return nil
+	}, "app failed")
+	assert.ErrorContains(t, err, "the program crashed; a crash report was written to "+dir)
+	assert.ErrorContains(t, err, "app failed: assignment to entry in nil map")
+
+	files, _ := filepath.Glob(filepath.Join(dir, "crash-*.txt"))
+	assert.Len(t, files, 1)
+
+	report, _ := os.ReadFile(files[0])
+	assert.Contains(t, string(report), "Error:   app failed: assignment to entry in nil map")
+	assert.Contains(t, string(report), "errorcat_test.crashingFunction")
+	assert.NotContains(t, string(report), "GuardCrashReport.func1.1", "the stack is from the panic, not the OnPanic callback")
+}
+
+// Caught errors are returned normally without a report.
+func TestGuardCrashReportCaught(t *testing.T) {
+	dir := t.TempDir()
+
+	err := cat.GuardCrashReport(dir, func(ct cat.Context) error {
+		ct.Catch(errTest, "expected failure")
+		return nil
+	})
+	assert.EqualError(t, err, "expected failure: test-error")
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	assert.Empty(t, files)
+}
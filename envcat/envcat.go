@@ -0,0 +1,69 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+/*
+This package provides Errorcat helpers for reading configuration from environment
+variables. A missing or invalid variable is caught with a message naming the variable, so
+startup validation can be funneled into a single guard.
+
+	func loadConfig() (cfg Config, rerr error) {
+		defer errorcat.Recover(&rerr, "invalid configuration")
+
+		cfg.DatabaseURL = envcat.CatchEnv("DATABASE_URL")
+		cfg.Port = envcat.CatchEnvInt("PORT")
+		cfg.Timeout = envcat.CatchEnvDuration("TIMEOUT")
+		return cfg, nil
+	}
+*/
+package envcat
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"go.mukunda.com/errorcat"
+)
+
+// Caught when a variable is not set or empty.
+var ErrMissing = errors.New("missing environment variable")
+
+// Caught when a variable can't be parsed.
+var ErrInvalid = errors.New("invalid environment variable")
+
+// Returns the value of the environment variable, catching [ErrMissing] if it's empty.
+// `problem` works the same as in [errorcat.Catch].
+func CatchEnv(name string, problem ...any) string {
+	value := os.Getenv(name)
+	if value == "" {
+		errorcat.Catch(fmt.Errorf("%w: %s", ErrMissing, name), problem...)
+	}
+	return value
+}
+
+// Reads the variable with [CatchEnv] and parses it, catching [ErrInvalid] if parsing
+// fails.
+func catchParsed[T any](name string, parse func(string) (T, error), problem []any) T {
+	value, err := parse(CatchEnv(name, problem...))
+	if err != nil {
+		errorcat.Catch(fmt.Errorf("%w: %s: %w", ErrInvalid, name, err), problem...)
+	}
+	return value
+}
+
+// Same as [CatchEnv], but the value is parsed as an integer.
+func CatchEnvInt(name string, problem ...any) int {
+	return catchParsed(name, strconv.Atoi, problem)
+}
+
+// Same as [CatchEnv], but the value is parsed as a boolean with strconv.ParseBool.
+func CatchEnvBool(name string, problem ...any) bool {
+	return catchParsed(name, strconv.ParseBool, problem)
+}
+
+// Same as [CatchEnv], but the value is parsed as a duration with time.ParseDuration.
+func CatchEnvDuration(name string, problem ...any) time.Duration {
+	return catchParsed(name, time.ParseDuration, problem)
+}
@@ -0,0 +1,72 @@
+package envcat_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mukunda.com/errorcat"
+	"go.mukunda.com/errorcat/envcat"
+)
+
+// Present variables are returned and parsed.
+func TestCatchEnvPresent(t *testing.T) {
+	t.Setenv("ENVCAT_STRING", "hello")
+	t.Setenv("ENVCAT_INT", "42")
+	t.Setenv("ENVCAT_BOOL", "true")
+	t.Setenv("ENVCAT_DURATION", "1m30s")
+
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		assert.Equal(t, "hello", envcat.CatchEnv("ENVCAT_STRING"))
+		assert.Equal(t, 42, envcat.CatchEnvInt("ENVCAT_INT"))
+		assert.Equal(t, true, envcat.CatchEnvBool("ENVCAT_BOOL"))
+		assert.Equal(t, 90*time.Second, envcat.CatchEnvDuration("ENVCAT_DURATION"))
+		return nil
+	})
+
+	assert.NoError(t, err)
+}
+
+// Missing variables are caught with the variable name.
+func TestCatchEnvMissing(t *testing.T) {
+	t.Setenv("ENVCAT_MISSING", "")
+
+	for _, fn := range []func(){
+		func() { envcat.CatchEnv("ENVCAT_MISSING", "bad config") },
+		func() { envcat.CatchEnvInt("ENVCAT_MISSING", "bad config") },
+		func() { envcat.CatchEnvBool("ENVCAT_MISSING", "bad config") },
+		func() { envcat.CatchEnvDuration("ENVCAT_MISSING", "bad config") },
+	} {
+		err := errorcat.Guard(func(ct errorcat.Context) error {
+			fn()
+			return nil
+		})
+
+		assert.Equal(t, "bad config: missing environment variable: ENVCAT_MISSING", err.Error())
+		assert.ErrorIs(t, err, envcat.ErrMissing)
+	}
+}
+
+// Unparseable variables are caught with the variable name and the parse error.
+func TestCatchEnvInvalid(t *testing.T) {
+	t.Setenv("ENVCAT_INVALID", "abc")
+
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		envcat.CatchEnvInt("ENVCAT_INVALID")
+		return nil
+	})
+	assert.Equal(t, `invalid environment variable: ENVCAT_INVALID: strconv.Atoi: parsing "abc": invalid syntax`, err.Error())
+	assert.ErrorIs(t, err, envcat.ErrInvalid)
+
+	err = errorcat.Guard(func(ct errorcat.Context) error {
+		envcat.CatchEnvBool("ENVCAT_INVALID")
+		return nil
+	})
+	assert.ErrorIs(t, err, envcat.ErrInvalid)
+
+	err = errorcat.Guard(func(ct errorcat.Context) error {
+		envcat.CatchEnvDuration("ENVCAT_INVALID", "bad config")
+		return nil
+	})
+	assert.Equal(t, `bad config: invalid environment variable: ENVCAT_INVALID: time: invalid duration "abc"`, err.Error())
+}
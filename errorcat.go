@@ -43,6 +43,10 @@ guard safety when you're working with multiple guard locations (e.g., library co
 		})
 	}
 
+Go doesn't allow type parameters on methods, so generic functions such as [Try],
+[CatchIndex], and [CatchErrorType] have no Context version. Call them directly from code
+that has a context; they are recovered by the context's guard like any other Catch.
+
 See the repo README.md for more information on usage.
 */
 package errorcat
@@ -50,27 +54,137 @@ package errorcat
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"runtime/debug"
+	"sync/atomic"
 )
 
 // This type implements the error interface and wraps any error originating from Catch.
 type CatError struct {
 	err error
+
+	// Set when the panic is recovered by this package. If it's never set, the message
+	// explains that there was no guard, as it's likely seen in a crash.
+	recovered *atomic.Bool
 }
 
 // Read the error message.
 func (e CatError) Error() string {
+	if e.recovered != nil && !e.recovered.Load() {
+		return "[errorcat] Catch called without an enclosing Recover on this goroutine — " +
+			"did you forget defer cat.Recover or launch a goroutine without a guard? " +
+			"Caught error: " + e.err.Error()
+	}
 	return e.err.Error()
 }
 
+// Marks the panic as recovered by this package. See [CatError.Error].
+func (e CatError) markRecovered() {
+	if e.recovered != nil {
+		e.recovered.Store(true)
+	}
+}
+
 // Get the wrapped catch error.
 func (e CatError) Unwrap() error {
 	return e.err
 }
 
+// Returns the problem given to Catch for an error condition, e.g., "couldn't write file".
+// This is for rendering the human-readable problem separately from the technical cause.
+// It's nil if no problem was given or the condition was boolean.
+func (e CatError) Problem() any {
+	return ProblemOf(e.err)
+}
+
+// Returns the underlying error that triggered Catch, without the problem. If
+// [CatError.Problem] is nil, this is the caught error.
+func (e CatError) Cause() error {
+	return CauseOf(e.err)
+}
+
+// Same as [CatError.Problem], but for an error that was already recovered, e.g., the one
+// returned by [Guard]. If problems were given more than once, e.g., by nested catches, the
+// outermost one is returned.
+func ProblemOf(err error) any {
+	var pe *problemError
+	if errors.As(err, &pe) {
+		return pe.problem
+	}
+	return nil
+}
+
+// Same as [CatError.Cause], but for an error that was already recovered. It returns `err`
+// if there is no problem in the chain.
+func CauseOf(err error) error {
+	var pe *problemError
+	if errors.As(err, &pe) {
+		return pe.cause
+	}
+	return err
+}
+
+// Retains the problem and the cause of a caught error separately. See [CatError.Problem].
+type problemError struct {
+	err     error
+	problem any
+	cause   error
+}
+
+func (e *problemError) Error() string {
+	return e.err.Error()
+}
+
+func (e *problemError) Unwrap() error {
+	return e.err
+}
+
+// The error type created by Catch when a boolean condition is given a non-error problem.
+// Errors with the same message are considered equal by errors.Is, so they can be
+// compared and grouped.
+type catchError struct {
+	msg string
+}
+
+func (e *catchError) Error() string {
+	return e.msg
+}
+
+func (e *catchError) Is(target error) bool {
+	t, ok := target.(*catchError)
+	return ok && t.msg == e.msg
+}
+
 // An annotator accepts a caught error and transforms it. These can also be used for
 // handling errors.
 type Annotator = func(err error) error
 
+// A terminal annotator that is called after all other annotators, with the list of string
+// annotations that were applied, in order. This is for final formatting, e.g., producing
+// a combined summary line.
+type SummaryAnnotator = func(err error, applied []string) error
+
+// A hook that is called with the final error after recovery, even if it's nil. See
+// [Finally].
+type FinallyHook func(err error)
+
+// Returns a hook for the annotate list of [Recover] or [Guard] that is called with the
+// final error after all annotators, including when there is no error. It can't alter the
+// error, so it's for cleanup that should always run, e.g., closing resources or recording
+// metrics.
+func Finally(fn func(err error)) FinallyHook {
+	return fn
+}
+
+// Calls the [FinallyHook] entries in an annotate list, in order.
+func runFinally(err error, annotate []any) {
+	for _, a := range annotate {
+		if hook, ok := a.(FinallyHook); ok {
+			hook(err)
+		}
+	}
+}
+
 // Callback for Guard.
 type GuardFunc = func(ct Context) error
 
@@ -92,91 +206,385 @@ If `annonate` arguments are given, the error is annotated with each one. These c
 strings, errors, or a callback Annotator function. Annotator functions also act as
 error handlers, to log or transform the error into a service response. Returning nil
 from a handler will prevent further annotators in the chain from being used.
+[SummaryAnnotator] functions are called after all other annotators. [Finally] hooks are
+called last with the final error, even when there is none.
 */
 func Recover(ctparam any, annotate ...any) {
-	var rerr *error
-	var ct Context
+	ct, rerr := recoverParam(ctparam)
+
+	// recover only works when called directly by the deferred function.
+	handleRecover(ct, rerr, recover(), panicPassthrough.Load(), recoverAnnotators(annotate))
+}
+
+/*
+Same as [Recover], but for a value that was already recovered, returning the resulting
+error. This is for code that calls recover itself, e.g., in a deferred function that does
+other work:
+
+	defer func() {
+		if err := cat.Recovered(recover(), "worker failed"); err != nil {
+			report(err)
+		}
+	}()
+
+The same rules as Recover apply: errors from Catch are unwrapped from their [CatError],
+real panics become a [PanicError], and the annotations are applied. A nil value returns
+nil, after calling any [Finally] hooks. Both functions share the same implementation.
+*/
+func Recovered(r any, annotate ...any) error {
+	var err error
+	handleRecover(nil, &err, r, panicPassthrough.Load(), recoverAnnotators(annotate))
+	return err
+}
+
+// Same as [Recover], but only errors propagated by Catch are recovered. Anything else,
+// e.g., a nil pointer dereference, is re-panicked so that real bugs crash loudly. This is
+// the same as Recover with [SetPanicPassthrough] enabled, but for a single guard.
+func RecoverStrict(ctparam any, annotate ...any) {
+	ct, rerr := recoverParam(ctparam)
+	handleRecover(ct, rerr, recover(), true, recoverAnnotators(annotate))
+}
+
+// Resolves the `ctparam` argument of [Recover].
+func recoverParam(ctparam any) (Context, *error) {
 	switch c := ctparam.(type) {
 	case *error:
-		rerr = c
+		return nil, c
 	case Context:
-		ct = c
-		rerr = ct.ErrorRef()
+		return c, c.ErrorRef()
 	case nil:
 		// No context.
 	default:
 		// Should we be strict and only allow nil? Panic on default?
 	}
+	return nil, nil
+}
+
+// Same as [Recover], but a captured panic is joined with the existing value of `*rerr`
+// using errors.Join, rather than replacing it. This is for functions that set `*rerr`
+// before a later Catch, e.g., when collecting errors manually. The annotations are applied
+// to the joined error.
+func RecoverJoin(rerr *error, annotate ...any) {
+	var panicked error
+	handleRecover(nil, &panicked, recover(), panicPassthrough.Load(), nil)
+	annotate = recoverAnnotators(annotate)
+
+	err := *rerr
+	if err == nil {
+		err = panicked
+	} else if panicked != nil {
+		err = errors.Join(err, panicked)
+	}
+
+	if err != nil {
+		err = annotateError(err, annotate)
+	}
+	runFinally(err, annotate)
+	*rerr = err
+}
 
+// Implements [Recover] once the panic value `r` is recovered. `ct` and `rerr` can be nil.
+// If `passthrough` is true, panics that didn't come from Catch are re-panicked.
+func handleRecover(ct Context, rerr *error, r any, passthrough bool, annotate []any) {
 	if ct != nil {
 		ct.OnRecover()
 	}
+	// Other Context implementations have none of the state below.
+	base := baseContext(ct)
 
-	// Recover from panic and capture the error.
+	// Capture the error.
 	var captured error
 	if rerr != nil {
 		captured = *rerr
 	}
 
-	if r := recover(); r != nil {
-		if e, ok := r.(error); ok {
-			captured = e
+	var fatal error
+	if r != nil {
+		if ce, ok := r.(CatError); ok {
+			// Before the handlers, so they see the plain message.
+			ce.markRecovered()
+		}
+		if base != nil {
+			for _, fn := range base.panicHandlers {
+				fn(r)
+			}
+		}
 
-			if e, ok := captured.(CatError); ok {
-				// Unwrap caught error.
-				captured = e.err
+		if _, ok := r.(CatError); !ok && passthrough {
+			panic(r)
+		}
+
+		if _, ok := r.(CatError); ok {
+			captured = panicError(r)
+			if base != nil && base.prefix != "" {
+				captured = fmt.Errorf("%s: %w", base.prefix, captured)
 			}
 		} else {
-			captured = fmt.Errorf("%v", r)
+			// The panicking stack hasn't unwound yet, so it can be captured here.
+			captured = &PanicError{Value: r, err: panicError(r), stack: debug.Stack()}
+		}
+		if SeverityOf(captured) == SeverityFatal {
+			fatal = captured
+		}
+	}
+
+	// Merge errors from sub-operations.
+	if base != nil {
+		if subErrors := base.subErrors; len(subErrors) > 0 {
+			captured = errors.Join(append([]error{captured}, subErrors...)...)
+		}
+	}
+
+	// Roll back completed steps.
+	if captured != nil && base != nil {
+		compensations := base.compensations
+		errs := []error{captured}
+		for i := len(compensations) - 1; i >= 0; i-- {
+			if err := callSafely(compensations[i]); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 1 {
+			captured = errors.Join(errs...)
 		}
 	}
 
 	// Annotate the error.
 	if captured != nil {
-		for _, annotator := range annotate {
-			switch a := annotator.(type) {
-			case Annotator:
-				captured = a(captured)
-			case error:
-				captured = fmt.Errorf("%w: %w", a, captured)
-			case string:
-				captured = fmt.Errorf("%s: %w", a, captured)
-			default:
-				// Unknown!
-				captured = fmt.Errorf("%v: %w", a, captured)
-			}
+		captured = annotateError(captured, annotate)
+	}
 
-			if captured == nil {
-				// Break the chain if it was handled by an annotator.
-				break
-			}
+	if fatal != nil {
+		// Fatal errors escape the guard, even if an annotator handled them. Outer guards
+		// will do the same after applying their own annotations.
+		if captured == nil {
+			captured = fatal
+		}
+		if SeverityOf(captured) != SeverityFatal {
+			captured = &severityError{err: captured, severity: SeverityFatal}
 		}
+		runFinally(captured, annotate)
+		panic(captured)
 	}
 
+	runFinally(captured, annotate)
 	if rerr != nil {
 		*rerr = captured
 	}
 }
 
+// Applies an annotate list to an error. See [Recover].
+func annotateError(err error, annotate []any) error {
+	var applied []string
+	var summaries []SummaryAnnotator
+
+	for _, annotator := range annotate {
+		switch a := annotator.(type) {
+		case Annotator:
+			err = a(err)
+		case SummaryAnnotator:
+			// Called after everything else.
+			summaries = append(summaries, a)
+			continue
+		case FinallyHook:
+			// Called by Recover with the final error.
+			continue
+		case error:
+			err = fmt.Errorf("%w: %w", a, err)
+		case string:
+			if e, ok := factoryError(a, err); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%s: %w", a, err)
+			}
+			applied = append(applied, a)
+		default:
+			// Unknown!
+			err = fmt.Errorf("%v: %w", a, err)
+		}
+
+		if err == nil {
+			// Break the chain if it was handled by an annotator.
+			return nil
+		}
+	}
+
+	for _, summary := range summaries {
+		err = summary(err, applied)
+		if err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// Calls a function, converting any panic into a returned error.
+func callSafely(fn func() error) (rerr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			rerr = panicError(r)
+		}
+	}()
+	return fn()
+}
+
+// The error captured by [Recover] for a real panic, i.e., one that didn't come from Catch.
+// It carries the stack of the panic, which is lost once the panic is recovered. If the
+// panic value is an error, it's wrapped, so it can be matched with errors.Is and
+// errors.As.
+type PanicError struct {
+	// The value passed to panic.
+	Value any
+
+	err   error
+	stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return e.err.Error()
+}
+
+func (e *PanicError) Unwrap() error {
+	return e.err
+}
+
+// Returns the stack trace of the goroutine at the time of the panic. Unlike calling
+// debug.Stack in an annotator, this includes the frames where the panic happened.
+func (e *PanicError) Stack() string {
+	return string(e.stack)
+}
+
+// Converts a recovered panic value into an error. Errors propagated by [Catch] are
+// unwrapped from their [CatError].
+func panicError(r any) error {
+	if e, ok := r.(error); ok {
+		if e, ok := e.(CatError); ok {
+			// Unwrap caught error.
+			e.markRecovered()
+			return e.err
+		}
+		return e
+	}
+	return fmt.Errorf("%v", r)
+}
+
 // This function creates a guarded context and calls the given function. Using the created
 // context is optional. Any errors that are captured will be returned to the caller.
 // `annotate` parameters can be used the same way as in [Recover].
 func Guard(fn GuardFunc, annotate ...any) (rerr error) {
+	return guard(fn, annotate)
+}
+
+// Implements [Guard]. This must be called directly by the exported function, so that the
+// guard site for tracing is the caller of that function.
+func guard(fn GuardFunc, annotate []any) (rerr error) {
+	if guardTrace.Load() {
+		// Runs after Recover.
+		defer traceGuard(&rerr, funcName(2))
+	}
+
 	ct := NewContext(&rerr)
 	defer Recover(ct, annotate...)
 	return fn(ct)
 }
 
+// Same as [Guard], but `fn` doesn't receive a context. This is for the common case where
+// only the package-level [Catch] is used:
+//
+//	err := cat.Run(func() error {
+//		cat.Catch(step1(), "step 1 failed")
+//		cat.Catch(step2(), "step 2 failed")
+//		return nil
+//	}, "setup failed")
+func Run(fn func() error, annotate ...any) error {
+	return guard(func(ct Context) error {
+		return fn()
+	}, annotate)
+}
+
+// Same as [Guard], but also returns the warnings recorded with [ExtendedContext.Warn]. This is for
+// operations that can succeed with caveats, e.g., a data import that skipped some bad
+// records. Warnings are returned whether or not the guard fails, and they are not
+// annotated.
+func GuardWarn(fn GuardFunc, annotate ...any) (error, []error) {
+	var ct Context
+	err := guard(func(c Context) error {
+		ct = c
+		return fn(c)
+	}, annotate)
+	return err, baseContext(ct).warnings
+}
+
+/*
+Returns a [Guard] with preset annotations. This is for types with many methods that each
+need a top-level guard, so that the annotation is defined once and applied consistently:
+
+	type UserService struct {
+		run func(cat.GuardFunc) error
+	}
+
+	func NewUserService() *UserService {
+		return &UserService{run: cat.GuardMethods("userservice")}
+	}
+
+	func (s *UserService) GetUser(id string) (user *User, rerr error) {
+		return user, s.run(func(ct cat.Context) error {
+			user = loadUser(ct, id)
+			return nil
+		})
+	}
+*/
+func GuardMethods(annotate ...any) func(fn GuardFunc) error {
+	return func(fn GuardFunc) error {
+		return guard(fn, annotate)
+	}
+}
+
 // This function calls the given function inside of a goroutine with a guarded context.
-// The error is returned to the caller through a channel.
+// The error is returned to the caller through a channel. If stack capture is enabled with
+// [SetCaptureStack], the error carries the stack that called Go, in addition to the stack
+// where it was caught. See [StackOf].
 func Go(fn GuardFunc, annotate ...any) chan error {
+	var launch []uintptr
+	if captureStack.Load() {
+		launch = callers()
+	}
+
 	ch := make(chan error)
 	go func() {
-		ch <- Guard(fn, annotate...)
+		ch <- withLaunch(Guard(fn, annotate...), launch)
 	}()
 	return ch
 }
 
+/*
+Guards the logic of a program's main function and returns an exit code: 0 on success, or
+1 if `fn` returns an error or panics. The error is passed to `onError` for logging, if it's
+not nil.
+
+	func main() {
+		os.Exit(cat.SafeMain(run, func(err error) {
+			log.Printf("fatal: %v", err)
+		}))
+	}
+
+Go has no way to recover panics from other goroutines, so this only covers the main
+goroutine. Background work should be launched with [Go] so that it has its own guard.
+*/
+func SafeMain(fn func() error, onError func(err error)) int {
+	err := Guard(func(ct Context) error {
+		return fn()
+	})
+	if err == nil {
+		return 0
+	}
+	if onError != nil {
+		onError(err)
+	}
+	return 1
+}
+
 /*
 [Catch] is for catching errors. In other words, it is "panic on error condition". The
 panic is recovered from by [Recover].
@@ -190,11 +598,156 @@ boolean, the propagated error will contain only the problem.
 
 If the `problem` is a string, it will be wrapped into an anonymous error type.
 `problem` is optional, but it is bad practice to not provide a problem if the condition
-is not an error.
+is not an error. See [SetRequireProblem] to enforce it.
+
+An error condition that holds a typed nil, e.g., a nil *os.PathError, is treated as no
+error.
 */
 func Catch(condition any, problem ...any) {
+	if err := caught(condition, problem); err != nil {
+		throw(err)
+	}
+}
+
+// Same as [Catch], but the problem is formatted with fmt.Sprintf. Formatting only happens
+// if the condition triggers, so there's no cost on the happy path:
+//
+//	cat.Catchf(err, "failed processing id %d", id)
+func Catchf(condition any, format string, args ...any) {
+	if triggered(condition) {
+		Catch(condition, fmt.Sprintf(format, args...))
+	}
+}
+
+// Catches the first error among `conditions` that isn't nil, and does nothing if they all
+// are. This is for fail-fast checks of several results with no per-error problem:
+//
+//	cat.CatchAll(w.Flush(), f.Sync(), f.Close())
+//
+// The arguments are all evaluated before the call, so this doesn't skip any work.
+func CatchAll(conditions ...error) {
+	for _, err := range conditions {
+		if triggered(err) {
+			Catch(err)
+		}
+	}
+}
+
+// Same as [CatchAll], but whichever error triggers is annotated with a problem formatted
+// with fmt.Sprintf:
+//
+//	cat.CatchAllf([]error{w.Flush(), f.Close()}, "couldn't save %s", name)
+func CatchAllf(conditions []error, format string, args ...any) {
+	for _, err := range conditions {
+		if triggered(err) {
+			Catch(err, fmt.Sprintf(format, args...))
+		}
+	}
+}
+
+// Same as [Catch] with error problems, but the condition and the problems are joined with
+// errors.Join instead of nesting them as "problem: condition". The members are siblings, so
+// each is found by errors.Is and errors.As, and the message has one line per member. Nil
+// problems are skipped.
+func CatchJoin(condition error, problems ...error) {
+	if triggered(condition) {
+		throw(errors.Join(append([]error{condition}, problems...)...))
+	}
+}
+
+/*
+Same as [Catch], but any value can be the condition. This is for interface values that may
+hold anything, avoiding [ErrBadCatch] when the dynamic type isn't a bool or error.
+
+  - nil is a success, including typed nils, e.g., a nil *MyErr in an error or any value.
+    `var e *MyErr; CatchAny(e)` does not catch.
+  - Errors and bools are handled the same as in Catch.
+  - Any other value triggers unless it's the zero value of its type, and the error is
+    formed from `problem` the same as a true boolean condition.
+*/
+func CatchAny(condition any, problem ...any) {
+	switch c := condition.(type) {
+	case error, bool:
+		if isNil(c) {
+			return
+		}
+		Catch(c, problem...)
+	default:
+		if isNil(c) || reflect.ValueOf(c).IsZero() {
+			return
+		}
+		Catch(true, problem...)
+	}
+}
+
+// Returns true if `v` is nil or holds a nil pointer, map, slice, channel, or function.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// Returns true if the condition would trigger [Catch]. Invalid conditions are considered
+// triggered so that Catch can report them.
+func triggered(condition any) bool {
+	switch c := condition.(type) {
+	case nil:
+		return false
+	case error:
+		return !isNil(c)
+	case bool:
+		return c
+	}
+	return true
+}
+
+// Propagates an error to the nearest guard.
+func throw(err error) {
+	if captureStack.Load() {
+		err = &stackError{err: err, pcs: callers()}
+	}
+	onCatch(err)
+	panic(CatError{err: err, recovered: new(atomic.Bool)})
+}
+
+// Returns the error that [Catch] propagates for the given arguments, or nil if the
+// condition isn't an error state. The result is not wrapped in [CatError].
+func caught(condition any, problem []any) error {
+	if condition == true && (len(problem) == 0 || problem[0] == nil) && requireProblem.Load() {
+		// Bad practice, enforced. This is only checked for Catch, not for warnings.
+		panic(fmt.Errorf("%w: a problem is required for boolean conditions", ErrBadCatch))
+	}
+	return formError(condition, problem)
+}
+
+// Same as [caught], but without the checks for bad Catch usage. This forms the warnings
+// from [Warn].
+func formError(condition any, problem []any) error {
+	if len(problem) == 0 || problem[0] == nil {
+		if p, ok := defaultProblemOf(condition); ok {
+			return &defaultedError{formError(condition, []any{p})}
+		}
+	}
+
+	err, problem1, cause := catchParts(condition, problem)
+	if problem1 == nil || cause == nil {
+		// Nothing to separate.
+		return err
+	}
+	return &problemError{err: err, problem: problem1, cause: cause}
+}
+
+// Implements [formError], also returning the problem and the cause that the error was built
+// from.
+func catchParts(condition any, problem []any) (error, any, error) {
 	if condition == nil {
-		return
+		return nil, nil, nil
 	}
 
 	var problem1 any
@@ -204,18 +757,29 @@ func Catch(condition any, problem ...any) {
 
 	switch cond := condition.(type) {
 	case error:
-		if cond != nil {
+		// A typed nil, e.g., a nil *os.PathError in an error interface, is not an error.
+		if !isNil(cond) {
+			conditionFirst := WrapOrder(wrapOrder.Load()) == ConditionFirst
 			switch p := problem1.(type) {
 			case error:
 				// Annotate condition with problem.
 				// Wrap both errors.
-				panic(CatError{fmt.Errorf("%w: %w", p, cond)})
+				if conditionFirst {
+					return fmt.Errorf("%w: %w", cond, p), p, cond
+				}
+				return fmt.Errorf("%w: %w", p, cond), p, cond
 			case nil:
 				// Bubble error condition without annotation.
-				panic(CatError{cond})
+				return cond, nil, cond
 			default:
 				// Annotate condition with problem.
-				panic(CatError{fmt.Errorf("%v: %w", p, cond)})
+				if err, ok := factoryError(fmt.Sprint(p), cond); ok {
+					return err, p, cond
+				}
+				if conditionFirst {
+					return fmt.Errorf("%w: %v", cond, p), p, cond
+				}
+				return fmt.Errorf("%v: %w", p, cond), p, cond
 			}
 		}
 
@@ -224,17 +788,48 @@ func Catch(condition any, problem ...any) {
 			switch p := problem1.(type) {
 			case error:
 				// Wrap the given error.
-				panic(CatError{p})
+				return p, p, nil
 			case nil:
 				// Bad practice. A problem should be specified.
-				panic(CatError{ErrUnknown})
+				return ErrUnknown, nil, nil
 			default:
 				// Create a general error.
-				panic(CatError{fmt.Errorf("%v", p)})
+				if err, ok := factoryError(fmt.Sprint(p), nil); ok {
+					return err, p, nil
+				}
+				return &catchError{msg: fmt.Sprint(p)}, p, nil
 			}
 		}
 
 	default:
-		panic(CatError{fmt.Errorf("%w: unknown catch condition type: %v", ErrBadCatch, condition)})
+		return fmt.Errorf("%w: unknown catch condition type: %v", ErrBadCatch, condition), nil, nil
 	}
+
+	return nil, nil, nil
+}
+
+/*
+[CatchAfter] is the same as [Catch], but `cleanup` is called before the error is
+propagated. This is for releasing resources that aren't covered by a defer, e.g., ones
+that were acquired conditionally. `cleanup` is not called if the condition doesn't trigger.
+
+If `cleanup` panics, the cleanup error is joined with the original error so that neither
+is lost.
+*/
+func CatchAfter(condition any, cleanup func(), problem ...any) {
+	err := caught(condition, problem)
+	if err == nil {
+		return
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.Join(err, panicError(r))
+			}
+		}()
+		cleanup()
+	}()
+
+	throw(err)
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -254,3 +255,590 @@ func TestErrorTypeEqualsSource(t *testing.T) {
 	})
 	assert.Equal(t, errTest, err2)
 }
+
+// CatchAfter runs the cleanup function before the error propagates, and only when the
+// condition triggers.
+func TestCatchAfter(t *testing.T) {
+	var steps []string
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchAfter(false, func() { steps = append(steps, "not-called") }, "problem")
+		cat.CatchAfter(nil, func() { steps = append(steps, "not-called") }, "problem")
+
+		defer func() {
+			// The cleanup has already run by the time the panic is unwinding.
+			steps = append(steps, "unwinding")
+		}()
+		cat.CatchAfter(errTest, func() { steps = append(steps, "cleanup") }, "problem")
+		steps = append(steps, "unreachable")
+		return nil
+	})
+
+	assert.Equal(t, []string{"cleanup", "unwinding"}, steps)
+	assert.Equal(t, "problem: test-error", err.Error())
+	assert.ErrorIs(t, err, errTest)
+}
+
+// If the cleanup panics, the original error is joined with the cleanup error.
+func TestCatchAfterFailedCleanup(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchAfter(errTest, func() {
+			cat.Catch(errTest2, "cleanup failed")
+		}, "problem")
+		return nil
+	})
+
+	assert.Equal(t, "problem: test-error\ncleanup failed: test-error2", err.Error())
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, errTest2)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchAfter(true, func() { panic("boom") }, "problem")
+		return nil
+	})
+
+	assert.Equal(t, "problem\nboom", err.Error())
+}
+
+// The CatError seen before recovery has the same message as the caught error, whatever
+// form of guard is used.
+func TestCatErrorMessage(t *testing.T) {
+	var seen []string
+	onPanic := func(r any) { seen = append(seen, r.(error).Error()) }
+
+	err := cat.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		ext.OnPanic(onPanic)
+		ct.Catch(errTest, "problem")
+		return nil
+	})
+	assert.EqualError(t, err, "problem: test-error")
+
+	func() {
+		ct := cat.NewContext(&err)
+		defer cat.Recover(ct)
+		ct.(cat.ExtendedContext).OnPanic(onPanic)
+		ct.Catch(errTest, "problem")
+	}()
+	assert.EqualError(t, err, "problem: test-error")
+
+	err = cat.Recovered(func() (r any) {
+		defer func() { r = recover() }()
+		cat.Catch(errTest, "problem")
+		return nil
+	}())
+	assert.EqualError(t, err, "problem: test-error")
+
+	assert.Equal(t, []string{"problem: test-error", "problem: test-error"}, seen)
+}
+
+// When Catch is called on a goroutine without a guard, the message of the escaping panic
+// explains what went wrong.
+func TestUnguardedCatchMessage(t *testing.T) {
+	const hint = "Catch called without an enclosing Recover on this goroutine"
+
+	done := make(chan any)
+	go func() {
+		defer func() {
+			done <- recover()
+		}()
+		cat.Catch(errTest, "problem")
+	}()
+
+	r := <-done
+	assert.IsType(t, cat.CatError{}, r)
+	assert.Contains(t, r.(error).Error(), hint)
+	assert.Contains(t, r.(error).Error(), "problem: test-error")
+
+	// Once recovered by Errorcat, the hint is gone.
+	err := cat.Recovered(r)
+	assert.EqualError(t, err, "problem: test-error")
+	assert.EqualError(t, r.(error), "problem: test-error")
+}
+
+// Errors created from boolean catches have a stable identity based on their message.
+func TestBooleanCatchIdentity(t *testing.T) {
+	catchProblem := func(problem string) error {
+		return cat.Guard(func(ct cat.Context) error {
+			ct.Catch(true, problem)
+			return nil
+		})
+	}
+
+	err1 := catchProblem("bad condition")
+	err2 := catchProblem("bad condition")
+	err3 := catchProblem("other condition")
+
+	assert.Equal(t, "bad condition", err1.Error())
+	assert.ErrorIs(t, err1, err2)
+	assert.ErrorIs(t, fmt.Errorf("annotated: %w", err1), err2)
+	assert.NotErrorIs(t, err1, err3)
+
+	// Boolean catches carry metadata the same way as error catches.
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchDoc(true, "https://example.com/bad-condition", "bad condition")
+		return nil
+	})
+
+	assert.ErrorIs(t, err, err1)
+	url, _ := cat.DocURL(err)
+	assert.Equal(t, "https://example.com/bad-condition", url)
+}
+
+// SafeMain returns an exit code and passes errors to the handler.
+func TestSafeMain(t *testing.T) {
+	var handled error
+	onError := func(err error) { handled = err }
+
+	code := cat.SafeMain(func() error {
+		panic("boom")
+	}, onError)
+	assert.Equal(t, 1, code)
+	assert.EqualError(t, handled, "boom")
+
+	code = cat.SafeMain(func() error {
+		cat.Catch(errTest, "startup failed")
+		return nil
+	}, onError)
+	assert.Equal(t, 1, code)
+	assert.EqualError(t, handled, "startup failed: test-error")
+
+	handled = nil
+	code = cat.SafeMain(func() error {
+		return nil
+	}, onError)
+	assert.Equal(t, 0, code)
+	assert.NoError(t, handled)
+
+	assert.Equal(t, 1, cat.SafeMain(func() error { return errTest }, nil))
+}
+
+type testService struct {
+	run func(cat.GuardFunc) error
+}
+
+func (s *testService) Lookup(id string) (name string, rerr error) {
+	return name, s.run(func(ct cat.Context) error {
+		ct.Catch(id == "", "id is empty")
+		name = "user " + id
+		return nil
+	})
+}
+
+func (s *testService) Delete(id string) error {
+	return s.run(func(ct cat.Context) error {
+		ct.Catch(errTest, "deleting "+id)
+		return nil
+	})
+}
+
+// Bound guards apply the preset annotations in every method.
+func TestGuardMethods(t *testing.T) {
+	s := &testService{run: cat.GuardMethods("userservice")}
+
+	name, err := s.Lookup("1")
+	assert.NoError(t, err)
+	assert.Equal(t, "user 1", name)
+
+	_, err = s.Lookup("")
+	assert.EqualError(t, err, "userservice: id is empty")
+
+	err = s.Delete("1")
+	assert.EqualError(t, err, "userservice: deleting 1: test-error")
+	assert.ErrorIs(t, err, errTest)
+}
+
+// Measures the error path of Catch: the problem wrap, the CatError boxed for the panic,
+// and the recovery.
+func BenchmarkCatchError(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = cat.Guard(func(ct cat.Context) error {
+			cat.Catch(errTest, "problem")
+			return nil
+		})
+	}
+}
+
+// Same as BenchmarkCatchError, but without a problem, so only the guard and panic cost
+// remain.
+func BenchmarkCatchErrorNoProblem(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = cat.Guard(func(ct cat.Context) error {
+			cat.Catch(errTest)
+			return nil
+		})
+	}
+}
+
+// RecoverJoin keeps the existing error and joins the caught one to it.
+func TestRecoverJoin(t *testing.T) {
+	process := func(preset error, fail bool) (rerr error) {
+		defer cat.RecoverJoin(&rerr, "process failed")
+		rerr = preset
+		cat.Catch(fail, errTest2)
+		return rerr
+	}
+
+	err := process(errTest, true)
+	assert.EqualError(t, err, "process failed: test-error\ntest-error2")
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, errTest2)
+
+	assert.Equal(t, "process failed: test-error", process(errTest, false).Error())
+	assert.Equal(t, "process failed: test-error2", process(nil, true).Error())
+	assert.NoError(t, process(nil, false))
+}
+
+type countingStringer struct {
+	calls *int
+}
+
+func (s countingStringer) String() string {
+	*s.calls++
+	return "item"
+}
+
+// Catchf formats the problem like a string problem, only when triggered.
+func TestCatchf(t *testing.T) {
+	calls := 0
+	item := countingStringer{&calls}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.Catchf(nil, "failed processing %v", item)
+		cat.Catchf(false, "failed processing %v", item)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, calls)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.Catchf(errTest, "failed processing %v %d", item, 5)
+		return nil
+	})
+	assert.EqualError(t, err, "failed processing item 5: test-error")
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, 1, calls)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.Catchf(true, "id %d is invalid", 5)
+		return nil
+	})
+	assert.EqualError(t, err, "id 5 is invalid")
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.Catchf(5, "bad")
+		return nil
+	})
+	assert.ErrorIs(t, err, cat.ErrBadCatch)
+}
+
+// Run guards a function without a context, the same as Guard.
+func TestRun(t *testing.T) {
+	err := cat.Run(func() error {
+		cat.Catch(errTest, "step failed")
+		return nil
+	}, "setup failed", func(err error) error {
+		return fmt.Errorf("handled: %w", err)
+	})
+	assert.EqualError(t, err, "handled: setup failed: step failed: test-error")
+	assert.ErrorIs(t, err, errTest)
+
+	err = cat.Run(func() error {
+		panic("boom")
+	})
+	assert.EqualError(t, err, "boom")
+
+	assert.Equal(t, errTest, cat.Run(func() error { return errTest }))
+	assert.NoError(t, cat.Run(func() error { return nil }))
+}
+
+// RecoverStrict captures caught errors but lets real panics through.
+func TestRecoverStrict(t *testing.T) {
+	strict := func(fn func()) (rerr error) {
+		defer cat.RecoverStrict(&rerr, "strict")
+		fn()
+		return nil
+	}
+
+	err := strict(func() { cat.Catch(errTest) })
+	assert.EqualError(t, err, "strict: test-error")
+	assert.ErrorIs(t, err, errTest)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = strict(func() { panic("boom") })
+	})
+
+	// Panic handlers still run before the re-panic.
+	var handled any
+	assert.PanicsWithValue(t, "boom", func() {
+		ct := cat.NewContext(new(error))
+		defer cat.RecoverStrict(ct)
+		ct.(cat.ExtendedContext).OnPanic(func(r any) { handled = r })
+		panic("boom")
+	})
+	assert.Equal(t, "boom", handled)
+}
+
+func panickingFunction() {
+	panic(errTest)
+}
+
+// Real panics are captured as PanicErrors with the stack of the panic.
+func TestPanicErrorStack(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		panickingFunction()
+		return nil
+	}, "outer")
+
+	assert.EqualError(t, err, "outer: test-error")
+	assert.ErrorIs(t, err, errTest)
+
+	var pe *cat.PanicError
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, errTest, pe.Value)
+	assert.Contains(t, pe.Stack(), "errorcat_test.panickingFunction")
+
+	// Caught errors are not PanicErrors.
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest)
+		return nil
+	})
+	assert.False(t, errors.As(err, &pe))
+}
+
+type customError struct{}
+
+func (e *customError) Error() string { return "custom error" }
+
+// CatchAny accepts any type of condition.
+func TestCatchAny(t *testing.T) {
+	var nilErr *customError
+	var nilMap map[string]int
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchAny(nil, "nil")
+		cat.CatchAny(nilErr, "typed nil")
+		cat.CatchAny(error(nilErr), "typed nil error")
+		cat.CatchAny(nilMap, "nil map")
+		cat.CatchAny(false, "false")
+		cat.CatchAny(0, "zero")
+		cat.CatchAny("", "empty string")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		condition any
+		message   string
+	}{
+		{errTest, "problem: test-error"},
+		{&customError{}, "problem: custom error"},
+		{true, "problem"},
+		{5, "problem"},
+		{"sentinel", "problem"},
+		{map[string]int{}, "problem"},
+	} {
+		err := cat.Guard(func(ct cat.Context) error {
+			cat.CatchAny(tc.condition, "problem")
+			return nil
+		})
+		assert.EqualError(t, err, tc.message, tc.condition)
+	}
+}
+
+// Typed nils in an error interface are not errors.
+func TestCatchTypedNil(t *testing.T) {
+	var pathErr *os.PathError
+	var err error = pathErr
+
+	result := cat.Guard(func(ct cat.Context) error {
+		ct.Catch(err)
+		ct.Catch(err, "problem")
+		cat.Catchf(err, "problem %d", 1)
+		ct.Catch(pathErr, errTest)
+		return nil
+	})
+	assert.NoError(t, result)
+}
+
+// Finally hooks run with the final error, even on success, and can't change it.
+func TestFinally(t *testing.T) {
+	var finalErr error
+	open := 0
+	load := func(fail bool) error {
+		open++
+		return cat.Guard(func(ct cat.Context) error {
+			ct.Catch(fail, "load failed")
+			return nil
+		}, "loading", cat.Finally(func(err error) {
+			open--
+			finalErr = err
+		}))
+	}
+
+	err := load(true)
+	assert.EqualError(t, err, "loading: load failed")
+	assert.Equal(t, err, finalErr)
+	assert.Equal(t, 0, open)
+
+	err = load(false)
+	assert.NoError(t, err)
+	assert.NoError(t, finalErr)
+	assert.Equal(t, 0, open)
+
+	// A handled error is reported as nil.
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest)
+		return nil
+	}, func(err error) error { return nil }, cat.Finally(func(err error) {
+		finalErr = err
+	}))
+	assert.NoError(t, err)
+	assert.NoError(t, finalErr)
+}
+
+// CatError retains the problem and the cause separately.
+func TestCatErrorProblemCause(t *testing.T) {
+	catchErr := func(condition any, problem ...any) cat.CatError {
+		var ce cat.CatError
+		cat.Guard(func(ct cat.Context) error {
+			defer func() {
+				ce = recover().(cat.CatError)
+			}()
+			cat.Catch(condition, problem...)
+			return nil
+		})
+		return ce
+	}
+
+	ce := catchErr(errTest, "couldn't write file")
+	assert.Equal(t, "couldn't write file", ce.Problem())
+	assert.Equal(t, errTest, ce.Cause())
+	assert.EqualError(t, ce.Unwrap(), "couldn't write file: test-error")
+	assert.ErrorIs(t, ce, errTest)
+
+	ce = catchErr(errTest, errTest2)
+	assert.Equal(t, errTest2, ce.Problem())
+	assert.Equal(t, errTest, ce.Cause())
+	assert.ErrorIs(t, ce, errTest)
+	assert.ErrorIs(t, ce, errTest2)
+
+	// Without a separate problem, the cause is the caught error.
+	ce = catchErr(errTest)
+	assert.Nil(t, ce.Problem())
+	assert.Equal(t, errTest, ce.Cause())
+
+	ce = catchErr(true, "bad state")
+	assert.Nil(t, ce.Problem())
+	assert.EqualError(t, ce.Cause(), "bad state")
+}
+
+// ProblemOf and CauseOf read the same parts from a recovered error.
+func TestProblemOfCauseOf(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest, "couldn't write file")
+		return nil
+	}, "saving failed")
+	assert.EqualError(t, err, "saving failed: couldn't write file: test-error")
+	assert.Equal(t, "couldn't write file", cat.ProblemOf(err))
+	assert.Equal(t, errTest, cat.CauseOf(err))
+
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest)
+		return nil
+	})
+	assert.Nil(t, cat.ProblemOf(err))
+	assert.Equal(t, errTest, cat.CauseOf(err))
+
+	assert.Nil(t, cat.ProblemOf(nil))
+	assert.Nil(t, cat.CauseOf(nil))
+}
+
+// CatchAll catches the first non-nil error.
+func TestCatchAll(t *testing.T) {
+	var nilPath *os.PathError
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchAll()
+		cat.CatchAll(nil, nil, nilPath)
+		cat.CatchAllf([]error{nil, nil}, "problem")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	reached := false
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchAll(nil, errTest, errTest2)
+		reached = true
+		return nil
+	})
+	assert.Equal(t, errTest, err)
+	assert.False(t, reached)
+
+	calls := 0
+	item := countingStringer{&calls}
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchAllf([]error{nil, errTest2, errTest}, "failed processing %v", item)
+		return nil
+	})
+	assert.EqualError(t, err, "failed processing item: test-error2")
+	assert.NotErrorIs(t, err, errTest)
+	assert.Equal(t, 1, calls)
+}
+
+// Recovered applies the same rules as Recover to an explicitly recovered value.
+func TestRecovered(t *testing.T) {
+	assert.NoError(t, cat.Recovered(nil, "annotation"))
+
+	recoverFrom := func(fn func()) (r any) {
+		defer func() { r = recover() }()
+		fn()
+		return nil
+	}
+
+	r := recoverFrom(func() { cat.Catch(errTest, "problem") })
+	assert.IsType(t, cat.CatError{}, r)
+	err := cat.Recovered(r, "annotation")
+	assert.EqualError(t, err, "annotation: problem: test-error")
+	assert.ErrorIs(t, err, errTest)
+
+	r = recoverFrom(func() { panic("boom") })
+	err = cat.Recovered(r)
+	var pe *cat.PanicError
+	assert.ErrorAs(t, err, &pe)
+	assert.Equal(t, "boom", pe.Value)
+
+	// Handlers can swallow the error.
+	r = recoverFrom(func() { cat.Catch(errTest) })
+	assert.NoError(t, cat.Recovered(r, func(err error) error { return nil }))
+}
+
+// CatchJoin joins the condition and the problems as siblings.
+func TestCatchJoin(t *testing.T) {
+	errProblem := errors.New("problem")
+	var nilPath *os.PathError
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchJoin(nil, errProblem)
+		cat.CatchJoin(nilPath, errProblem)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchJoin(errTest, errProblem, nil, errTest2)
+		return nil
+	})
+	assert.EqualError(t, err, "test-error\nproblem\ntest-error2")
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, errProblem)
+	assert.ErrorIs(t, err, errTest2)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchJoin(errTest)
+		return nil
+	})
+	assert.ErrorIs(t, err, errTest)
+}
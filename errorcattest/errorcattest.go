@@ -0,0 +1,65 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+/*
+This package provides test helpers for code that uses Errorcat, replacing the usual
+recover boilerplate:
+
+	func TestLoadConfig(t *testing.T) {
+		errorcattest.AssertCatches(t, os.ErrNotExist, func() {
+			loadConfig("missing.json")
+		})
+	}
+*/
+package errorcattest
+
+import (
+	"errors"
+	"testing"
+
+	"go.mukunda.com/errorcat"
+)
+
+// Runs fn and returns the error propagated by Catch, unwrapped from its CatError the same
+// way as Recover. If fn panics with something else, the panic value is returned instead.
+func run(fn func()) (err error, other any) {
+	defer func() {
+		r := recover()
+		if ce, ok := r.(errorcat.CatError); ok {
+			err = ce.Unwrap()
+		} else {
+			other = r
+		}
+	}()
+	fn()
+	return nil, nil
+}
+
+// Fails the test unless fn calls Catch with an error that matches `target` with
+// errors.Is. The comparison is against the caught error, not the CatError wrapper, so
+// sentinel errors can be used directly. Returns the caught error for further checks.
+func AssertCatches(t testing.TB, target error, fn func()) error {
+	t.Helper()
+	err, other := run(fn)
+	switch {
+	case other != nil:
+		t.Errorf("expected a Catch of %q, but panicked with: %v", target, other)
+	case err == nil:
+		t.Errorf("expected a Catch of %q, but nothing was caught", target)
+	case !errors.Is(err, target):
+		t.Errorf("expected a Catch of %q, but caught: %v", target, err)
+	}
+	return err
+}
+
+// Fails the test if fn calls Catch or panics.
+func AssertNoCatch(t testing.TB, fn func()) {
+	t.Helper()
+	err, other := run(fn)
+	switch {
+	case other != nil:
+		t.Errorf("expected no Catch, but panicked with: %v", other)
+	case err != nil:
+		t.Errorf("expected no Catch, but caught: %v", err)
+	}
+}
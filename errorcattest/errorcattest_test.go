@@ -0,0 +1,59 @@
+package errorcattest_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mukunda.com/errorcat"
+	"go.mukunda.com/errorcat/errorcattest"
+)
+
+var errNotFound = errors.New("not found")
+
+// Records failures instead of failing the real test.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// Passing assertions don't fail the test.
+func TestAssertionsPass(t *testing.T) {
+	err := errorcattest.AssertCatches(t, errNotFound, func() {
+		errorcat.Catch(fmt.Errorf("user 5: %w", errNotFound), "lookup failed")
+	})
+	assert.EqualError(t, err, "lookup failed: user 5: not found")
+
+	errorcattest.AssertNoCatch(t, func() {
+		errorcat.Catch(nil, "lookup failed")
+	})
+}
+
+// Mismatches are reported as test failures.
+func TestAssertionsFail(t *testing.T) {
+	r := &recorder{}
+	errorcattest.AssertCatches(r, errNotFound, func() {})
+	errorcattest.AssertCatches(r, errNotFound, func() {
+		errorcat.Catch(true, "something else")
+	})
+	errorcattest.AssertCatches(r, errNotFound, func() { panic("boom") })
+	errorcattest.AssertNoCatch(r, func() {
+		errorcat.Catch(errNotFound)
+	})
+	errorcattest.AssertNoCatch(r, func() { panic("boom") })
+
+	assert.Equal(t, []string{
+		`expected a Catch of "not found", but nothing was caught`,
+		`expected a Catch of "not found", but caught: something else`,
+		`expected a Catch of "not found", but panicked with: boom`,
+		`expected no Catch, but caught: not found`,
+		`expected no Catch, but panicked with: boom`,
+	}, r.failures)
+}
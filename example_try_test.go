@@ -0,0 +1,35 @@
+package errorcat_test
+
+import (
+	"fmt"
+	"strings"
+
+	cat "go.mukunda.com/errorcat"
+)
+
+// Splits "key=value" into its parts.
+func splitPair(s string) (string, string, error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return "", "", fmt.Errorf("missing '=' in %q", s)
+	}
+	return key, value, nil
+}
+
+// Example of catching errors from functions that return multiple values.
+func ExampleTry2() {
+	parse := func(s string) error {
+		return cat.Guard(func(ct cat.Context) error {
+			key, value := cat.Try2(splitPair(s))
+			fmt.Printf("%s is %s\n", key, value)
+			return nil
+		}, "invalid setting")
+	}
+
+	fmt.Println(parse("color=blue"))
+	fmt.Println(parse("color"))
+	// Output:
+	// color is blue
+	// <nil>
+	// invalid setting: missing '=' in "color"
+}
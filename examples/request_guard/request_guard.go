@@ -127,8 +127,14 @@ func handleError(err error) error {
 	// specific construct to contain user instructions.
 	fmt.Println("Oops! An internal error occurred!")
 
-	// Log the stack trace internally.
-	logError("An error occurred: %v\ntrace: %s\n", err, string(debug.Stack()))
+	// Log the stack trace internally. For real panics, use the stack of the panic rather
+	// than this handler.
+	trace := string(debug.Stack())
+	var panicErr *cat.PanicError
+	if errors.As(err, &panicErr) {
+		trace = panicErr.Stack()
+	}
+	logError("An error occurred: %v\ntrace: %s\n", err, trace)
 
 	return nil // The error is handled and will not be forwarded.
 }
@@ -0,0 +1,114 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	gocontext "context"
+	"errors"
+	"sync"
+)
+
+// Callback for [GoRace]. `ctx` is canceled when another function fails.
+type RaceFunc = func(ctx gocontext.Context, ct Context) error
+
+/*
+Runs each function in its own guarded goroutine and waits for all of them to finish. The
+errors are joined with errors.Join in the order of `fns`, or nil is returned if all of the
+functions succeed. Each function is guarded individually, so a failure in one doesn't stop
+the others.
+
+	err := cat.GoAll(
+		func(ct cat.Context) error { return loadUsers(ct) },
+		func(ct cat.Context) error { return loadOrders(ct) },
+	)
+*/
+func GoAll(fns ...GuardFunc) error {
+	var launch []uintptr
+	if captureStack.Load() {
+		launch = callers()
+	}
+	return goLimit(0, launch, fns)
+}
+
+// Same as [GoAll], but at most `limit` functions run at once. This is for large numbers of
+// tasks, e.g., one per file in a directory. A `limit` of zero or less is unbounded.
+func GoLimit(limit int, fns ...GuardFunc) error {
+	var launch []uintptr
+	if captureStack.Load() {
+		launch = callers()
+	}
+	return goLimit(limit, launch, fns)
+}
+
+// Implements [GoAll] and [GoLimit].
+func goLimit(limit int, launch []uintptr, fns []GuardFunc) error {
+	var sem chan struct{}
+	if limit > 0 {
+		sem = make(chan struct{}, limit)
+	}
+
+	errs := make([]error, len(fns))
+	var wg sync.WaitGroup
+	for i, fn := range fns {
+		if sem != nil {
+			// Wait for a free slot before launching, so that there aren't goroutines
+			// waiting for every task.
+			sem <- struct{}{}
+		}
+		wg.Add(1)
+		go func(i int, fn GuardFunc) {
+			defer wg.Done()
+			if sem != nil {
+				defer func() { <-sem }()
+			}
+			errs[i] = withLaunch(Guard(fn), launch)
+		}(i, fn)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+/*
+Runs each function in its own guarded goroutine and returns the first error, or nil if all
+of them succeed. When a function fails, including by panicking, the context passed to the
+others is canceled so that they can bail out early. This is the errgroup pattern with
+Errorcat guards:
+
+	err := cat.GoRace(ctx,
+		func(ctx context.Context, ct cat.Context) error { return fetchPrimary(ctx, ct) },
+		func(ctx context.Context, ct cat.Context) error { return fetchReplica(ctx, ct) },
+	)
+
+GoRace waits for all of the functions to return, so they should respect cancellation.
+*/
+func GoRace(ctx gocontext.Context, fns ...RaceFunc) error {
+	var launch []uintptr
+	if captureStack.Load() {
+		launch = callers()
+	}
+
+	ctx, cancel := gocontext.WithCancel(ctx)
+	defer cancel()
+
+	var first error
+	var once sync.Once
+	var wg sync.WaitGroup
+	for _, fn := range fns {
+		wg.Add(1)
+		go func(fn RaceFunc) {
+			defer wg.Done()
+			err := Guard(func(ct Context) error {
+				return fn(ctx, ct)
+			})
+			if err != nil {
+				once.Do(func() {
+					first = withLaunch(err, launch)
+					cancel()
+				})
+			}
+		}(fn)
+	}
+	wg.Wait()
+	return first
+}
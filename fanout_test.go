@@ -0,0 +1,124 @@
+package errorcat_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// GoAll runs every task and joins the errors in order.
+func TestGoAll(t *testing.T) {
+	var completed atomic.Int32
+	var others sync.WaitGroup
+	others.Add(3)
+	task := func(condition any, problem string) cat.GuardFunc {
+		return func(ct cat.Context) error {
+			defer others.Done()
+			defer completed.Add(1)
+			ct.Catch(condition, problem)
+			return nil
+		}
+	}
+
+	// The first task finishes last, but its error still comes first.
+	err := cat.GoAll(
+		func(ct cat.Context) error {
+			defer completed.Add(1)
+			others.Wait()
+			ct.Catch(errTest, "task 1")
+			return nil
+		},
+		task(nil, "task 2"),
+		func(ct cat.Context) error {
+			defer others.Done()
+			defer completed.Add(1)
+			panic("task 3 crashed")
+		},
+		task(errTest2, "task 4"),
+	)
+	assert.EqualError(t, err, "task 1: test-error\ntask 3 crashed\ntask 4: test-error2")
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, errTest2)
+	assert.Equal(t, int32(4), completed.Load())
+
+	others.Add(2)
+	assert.NoError(t, cat.GoAll(task(nil, "ok"), task(false, "ok")))
+	assert.NoError(t, cat.GoAll())
+}
+
+// GoLimit never runs more than the limit at once.
+func TestGoLimit(t *testing.T) {
+	var running, peak atomic.Int32
+	task := func(fail bool) cat.GuardFunc {
+		return func(ct cat.Context) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			ct.Catch(fail, "task failed")
+			return nil
+		}
+	}
+
+	var fns []cat.GuardFunc
+	for i := 0; i < 50; i++ {
+		fns = append(fns, task(i == 10))
+	}
+
+	err := cat.GoLimit(4, fns...)
+	assert.EqualError(t, err, "task failed")
+	assert.LessOrEqual(t, peak.Load(), int32(4))
+	assert.Equal(t, int32(0), running.Load())
+
+	// Zero is unbounded.
+	peak.Store(0)
+	assert.NoError(t, cat.GoLimit(0, fns[:5]...))
+	assert.NoError(t, cat.GoLimit(-1))
+}
+
+// The first failure cancels the others and is returned.
+func TestGoRace(t *testing.T) {
+	waitForCancel := func(ctx context.Context, ct cat.Context) error {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(5 * time.Second):
+			return errTest2
+		}
+	}
+
+	err := cat.GoRace(context.Background(),
+		waitForCancel,
+		func(ctx context.Context, ct cat.Context) error {
+			ct.Catch(errTest, "fetch failed")
+			return nil
+		},
+		waitForCancel,
+	)
+	assert.EqualError(t, err, "fetch failed: test-error")
+
+	// Panics are errors too.
+	err = cat.GoRace(context.Background(),
+		waitForCancel,
+		func(ctx context.Context, ct cat.Context) error {
+			panic("boom")
+		},
+	)
+	assert.EqualError(t, err, "boom")
+
+	err = cat.GoRace(context.Background(),
+		func(ctx context.Context, ct cat.Context) error { return nil },
+		func(ctx context.Context, ct cat.Context) error { return ctx.Err() },
+	)
+	assert.NoError(t, err)
+}
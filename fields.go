@@ -0,0 +1,114 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Returns m[key], catching an error if the key is missing. This is for dynamic JSON
+// decoded into a map[string]any.
+func CatchField(m map[string]any, key string, problem ...any) any {
+	v, ok := m[key]
+	if !ok {
+		Catch(fmt.Errorf("missing field %q", key), problem...)
+	}
+	return v
+}
+
+// Same as [CatchField], but also catches if the value is not a string.
+func CatchStringField(m map[string]any, key string, problem ...any) string {
+	v := CatchField(m, key, problem...)
+	s, ok := v.(string)
+	if !ok {
+		Catch(fmt.Errorf("field %q is %T, expected string", key, v), problem...)
+	}
+	return s
+}
+
+// Same as [CatchField], but also catches if the value is not an integer. JSON numbers
+// are decoded as float64 (or json.Number), so those are accepted if they hold a whole
+// number that fits in an int.
+func CatchIntField(m map[string]any, key string, problem ...any) int {
+	v := CatchField(m, key, problem...)
+	switch n := v.(type) {
+	case int:
+		return n
+	case int64:
+		if n >= math.MinInt && n <= math.MaxInt {
+			return int(n)
+		}
+	case float64:
+		// float64(math.MaxInt) rounds up past the range, so the upper bound is exclusive.
+		if n == math.Trunc(n) && n >= math.MinInt && n < -math.MinInt {
+			return int(n)
+		}
+	case json.Number:
+		if i, err := n.Int64(); err == nil && i >= math.MinInt && i <= math.MaxInt {
+			return int(i)
+		}
+	}
+	Catch(fmt.Errorf("field %q is not an integer: %v", key, v), problem...)
+	return 0
+}
+
+// Returns whether the named field of struct `v` is the zero value. An error wrapping
+// [ErrBadCatch] is returned if `v` isn't a struct or the field can't be checked.
+func isZeroField(v any, name string) (bool, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return false, fmt.Errorf("%w: %T is nil", ErrBadCatch, v)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return false, fmt.Errorf("%w: %T is not a struct", ErrBadCatch, v)
+	}
+
+	field, ok := rv.Type().FieldByName(name)
+	if !ok {
+		return false, fmt.Errorf("%w: %s has no field %q", ErrBadCatch, rv.Type(), name)
+	}
+	if !field.IsExported() {
+		return false, fmt.Errorf("%w: field %q of %s is unexported", ErrBadCatch, name, rv.Type())
+	}
+	fv, err := rv.FieldByIndexErr(field.Index)
+	if err != nil {
+		return false, fmt.Errorf("%w: field %q of %s is behind a nil embedded pointer", ErrBadCatch, name, rv.Type())
+	}
+	return fv.IsZero(), nil
+}
+
+// Catches if the named field of a struct (or pointer to a struct) is the zero value, e.g.,
+// "required field "Name" is not set". This is for checking that required fields of a
+// decoded struct were populated, when tag-based validation is overkill. Missing and
+// unexported fields are caught as [ErrBadCatch], since they're programming errors.
+func CatchZeroField(v any, fieldName string, problem ...any) {
+	zero, err := isZeroField(v, fieldName)
+	Catch(err, problem...)
+	if zero {
+		Catch(fmt.Errorf("required field %q is not set", fieldName), problem...)
+	}
+}
+
+// Same as [CatchZeroField], but checks several fields and names all of the ones that
+// aren't set, e.g., "required fields not set: Name, Email".
+func CatchRequiredFields(v any, names ...string) {
+	var missing []string
+	for _, name := range names {
+		zero, err := isZeroField(v, name)
+		Catch(err)
+		if zero {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		Catch(fmt.Errorf("required fields not set: %s", strings.Join(missing, ", ")))
+	}
+}
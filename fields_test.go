@@ -0,0 +1,147 @@
+package errorcat_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+func decodeJSON(t *testing.T, text string) map[string]any {
+	var m map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(text), &m))
+	return m
+}
+
+// Present fields of the right type are returned.
+func TestCatchFields(t *testing.T) {
+	m := decodeJSON(t, `{"name": "bob", "age": 42, "tags": null}`)
+
+	err := cat.Guard(func(ct cat.Context) error {
+		assert.Nil(t, cat.CatchField(m, "tags"))
+		assert.Equal(t, "bob", cat.CatchStringField(m, "name"))
+		// JSON numbers are float64, but whole numbers are accepted as ints.
+		assert.Equal(t, 42, cat.CatchIntField(m, "age"))
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+// Missing fields are caught.
+func TestCatchFieldMissing(t *testing.T) {
+	m := decodeJSON(t, `{}`)
+
+	for _, fn := range []func(ct cat.Context){
+		func(ct cat.Context) { cat.CatchField(m, "name", "bad user") },
+		func(ct cat.Context) { cat.CatchStringField(m, "name", "bad user") },
+		func(ct cat.Context) { cat.CatchIntField(m, "name", "bad user") },
+	} {
+		err := cat.Guard(func(ct cat.Context) error {
+			fn(ct)
+			return nil
+		})
+		assert.EqualError(t, err, `bad user: missing field "name"`)
+	}
+}
+
+// Fields of the wrong type are caught.
+func TestCatchFieldWrongType(t *testing.T) {
+	m := decodeJSON(t, `{"name": 123, "age": "old", "height": 1.5}`)
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchStringField(m, "name")
+		return nil
+	})
+	assert.EqualError(t, err, `field "name" is float64, expected string`)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchIntField(m, "age")
+		return nil
+	})
+	assert.EqualError(t, err, `field "age" is not an integer: old`)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchIntField(m, "height")
+		return nil
+	})
+	assert.EqualError(t, err, `field "height" is not an integer: 1.5`)
+}
+
+// Whole numbers outside of the int range are caught.
+func TestCatchIntFieldRange(t *testing.T) {
+	m := map[string]any{"big": math.Pow(2, 63), "small": -math.Pow(2, 63)}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchIntField(m, "big")
+		return nil
+	})
+	assert.EqualError(t, err, `field "big" is not an integer: 9.223372036854776e+18`)
+
+	if math.MaxInt == math.MaxInt64 {
+		err = cat.Guard(func(ct cat.Context) error {
+			assert.Equal(t, math.MinInt, cat.CatchIntField(m, "small"))
+			return nil
+		})
+		assert.NoError(t, err)
+	}
+}
+
+type userRecord struct {
+	Name   string
+	Email  string
+	Age    int
+	secret string
+}
+
+// Zero fields are caught by name.
+func TestCatchZeroField(t *testing.T) {
+	user := &userRecord{Name: "alice", secret: "x"}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchZeroField(user, "Name")
+		cat.CatchZeroField(*user, "Name")
+		cat.CatchRequiredFields(user, "Name")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchZeroField(user, "Email", "invalid user")
+		return nil
+	})
+	assert.EqualError(t, err, `invalid user: required field "Email" is not set`)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchRequiredFields(user, "Name", "Email", "Age")
+		return nil
+	})
+	assert.EqualError(t, err, "required fields not set: Email, Age")
+}
+
+type outerRecord struct {
+	*userRecord
+}
+
+// Fields that can't be checked are usage errors.
+func TestCatchZeroFieldBadUsage(t *testing.T) {
+	for _, tc := range []struct {
+		v       any
+		field   string
+		message string
+	}{
+		{userRecord{}, "Bogus", `bad catch usage: errorcat_test.userRecord has no field "Bogus"`},
+		{userRecord{}, "secret", `bad catch usage: field "secret" of errorcat_test.userRecord is unexported`},
+		{(*userRecord)(nil), "Name", "bad catch usage: *errorcat_test.userRecord is nil"},
+		{"text", "Name", "bad catch usage: string is not a struct"},
+		{outerRecord{}, "Name", `bad catch usage: field "Name" of errorcat_test.outerRecord is behind a nil embedded pointer`},
+	} {
+		err := cat.Guard(func(ct cat.Context) error {
+			cat.CatchZeroField(tc.v, tc.field)
+			return nil
+		})
+		assert.ErrorIs(t, err, cat.ErrBadCatch)
+		assert.EqualError(t, err, tc.message)
+	}
+}
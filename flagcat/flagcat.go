@@ -0,0 +1,70 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+/*
+This package provides Errorcat helpers for the standard flag package, so that command line
+errors are reported by the same top-level handler as other errors.
+
+	func main() {
+		fs := flag.NewFlagSet("mytool", flag.ContinueOnError)
+		input := fs.String("input", "", "input file")
+
+		os.Exit(errorcat.SafeMain(func() error {
+			flagcat.CatchParse(fs, os.Args[1:])
+			flagcat.CatchRequired(fs, "input")
+			return run(*input)
+		}, func(err error) {
+			fmt.Fprintln(os.Stderr, err)
+			if errors.Is(err, flagcat.ErrUsage) {
+				fs.Usage()
+			}
+		}))
+	}
+*/
+package flagcat
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"go.mukunda.com/errorcat"
+)
+
+// Caught for invalid command line usage, so that handlers can print the usage text.
+var ErrUsage = errors.New("usage error")
+
+// Parses the arguments with the flag set and catches [ErrUsage] if parsing fails. If the
+// arguments request help with -h or -help, the error also wraps flag.ErrHelp, which
+// programs typically treat as a successful exit. `problem` works the same as in
+// [errorcat.Catch].
+//
+// The flag set should use flag.ContinueOnError, as the other modes exit or panic on their
+// own. Note that the flag set prints the error and its usage to its output when parsing
+// fails, unless the output is redirected with SetOutput.
+func CatchParse(fs *flag.FlagSet, args []string, problem ...any) {
+	if err := fs.Parse(args); err != nil {
+		errorcat.Catch(fmt.Errorf("%w: %w", ErrUsage, err), problem...)
+	}
+}
+
+// Catches [ErrUsage] if any of the named flags weren't set on the command line, naming
+// all of the missing flags. Call this after parsing.
+func CatchRequired(fs *flag.FlagSet, names ...string) {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	var missing []string
+	for _, name := range names {
+		if !set[name] {
+			missing = append(missing, "-"+name)
+		}
+	}
+	if len(missing) > 0 {
+		errorcat.Catch(fmt.Errorf("%w: missing required flags: %s",
+			ErrUsage, strings.Join(missing, ", ")))
+	}
+}
@@ -0,0 +1,61 @@
+package flagcat_test
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mukunda.com/errorcat"
+	"go.mukunda.com/errorcat/flagcat"
+)
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.String("input", "", "input file")
+	fs.String("output", "", "output file")
+	fs.Bool("verbose", false, "verbose output")
+	return fs
+}
+
+// Valid command lines pass.
+func TestCatchParseValid(t *testing.T) {
+	fs := newFlagSet()
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		flagcat.CatchParse(fs, []string{"-input", "a.txt", "-output", "b.txt", "extra"})
+		flagcat.CatchRequired(fs, "input", "output")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"extra"}, fs.Args())
+}
+
+// Parse errors are caught as usage errors.
+func TestCatchParseError(t *testing.T) {
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		flagcat.CatchParse(newFlagSet(), []string{"-bogus"}, "bad arguments")
+		return nil
+	})
+	assert.ErrorIs(t, err, flagcat.ErrUsage)
+	assert.EqualError(t, err, "bad arguments: usage error: flag provided but not defined: -bogus")
+
+	err = errorcat.Guard(func(ct errorcat.Context) error {
+		flagcat.CatchParse(newFlagSet(), []string{"-h"})
+		return nil
+	})
+	assert.ErrorIs(t, err, flagcat.ErrUsage)
+	assert.ErrorIs(t, err, flag.ErrHelp)
+}
+
+// Missing required flags are named together.
+func TestCatchRequired(t *testing.T) {
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		fs := newFlagSet()
+		flagcat.CatchParse(fs, []string{"-verbose"})
+		flagcat.CatchRequired(fs, "input", "verbose", "output")
+		return nil
+	})
+	assert.ErrorIs(t, err, flagcat.ErrUsage)
+	assert.EqualError(t, err, "usage error: missing required flags: -input, -output")
+}
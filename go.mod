@@ -1,6 +1,6 @@
 module go.mukunda.com/errorcat
 
-go 1.18
+go 1.21
 
 require github.com/stretchr/testify v1.10.0
 
@@ -0,0 +1,18 @@
+module go.mukunda.com/errorcat/grpccat
+
+go 1.21
+
+require (
+	github.com/stretchr/testify v1.10.0
+	go.mukunda.com/errorcat v0.2.0
+	google.golang.org/grpc v1.65.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240528184218-531527333157 // indirect
+	google.golang.org/protobuf v1.34.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,179 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+/*
+This package converts caught errors into gRPC statuses. It lives in its own module so that
+the core package doesn't depend on gRPC.
+
+	func (s *server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+		var user *pb.User
+		err := errorcat.Guard(func(ct errorcat.Context) error {
+			user = lookupUser(ct, req.Id)
+			return nil
+		}, "GetUser failed", grpccat.Annotator())
+		return user, err
+	}
+
+The gRPC code comes from the code attached with errorcat.CatchCode, if any, and otherwise
+from the sentinel errors in the chain:
+
+	errorcat.CatchCode(user == nil, "not-found", "user not found") // codes.NotFound
+*/
+package grpccat
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"reflect"
+
+	"go.mukunda.com/errorcat"
+	"go.mukunda.com/errorcat/sqlcat"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The table used by default for codes attached with errorcat.CatchCode. It has common tags
+// and their HTTP status counterparts. A codes.Code attached directly is used as is.
+var DefaultCodes = map[any]codes.Code{
+	"bad-request":       codes.InvalidArgument,
+	"invalid-argument":  codes.InvalidArgument,
+	"unauthenticated":   codes.Unauthenticated,
+	"permission-denied": codes.PermissionDenied,
+	"forbidden":         codes.PermissionDenied,
+	"not-found":         codes.NotFound,
+	"conflict":          codes.AlreadyExists,
+	"already-exists":    codes.AlreadyExists,
+	"rate-limited":      codes.ResourceExhausted,
+	"unavailable":       codes.Unavailable,
+	"timeout":           codes.DeadlineExceeded,
+	"unimplemented":     codes.Unimplemented,
+	400:                 codes.InvalidArgument,
+	401:                 codes.Unauthenticated,
+	403:                 codes.PermissionDenied,
+	404:                 codes.NotFound,
+	409:                 codes.AlreadyExists,
+	429:                 codes.ResourceExhausted,
+	501:                 codes.Unimplemented,
+	503:                 codes.Unavailable,
+	504:                 codes.DeadlineExceeded,
+}
+
+// Maps errors matching Target (with errors.Is) to a gRPC code.
+type Mapping struct {
+	Target error
+	Code   codes.Code
+}
+
+// The sentinel mappings used by default. They're checked when the error has no code that
+// maps to a gRPC code. Errors that don't match anything are codes.Internal.
+var DefaultMappings = []Mapping{
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+	{context.Canceled, codes.Canceled},
+	{sqlcat.ErrNotFound, codes.NotFound},
+	{os.ErrNotExist, codes.NotFound},
+	{os.ErrPermission, codes.PermissionDenied},
+	{errorcat.ErrReentrant, codes.Aborted},
+}
+
+// Converts errors into gRPC statuses using a code table and a list of sentinel mappings.
+type Mapper struct {
+	codes    map[any]codes.Code
+	mappings []Mapping
+}
+
+// Creates a [Mapper] with [DefaultCodes]. The overrides are checked in order before
+// [DefaultMappings], and the first match wins.
+func NewMapper(overrides ...Mapping) *Mapper {
+	mappings := append([]Mapping{}, overrides...)
+	mappings = append(mappings, DefaultMappings...)
+	return &Mapper{codes: DefaultCodes, mappings: mappings}
+}
+
+// Returns a copy of the mapper with entries added to its code table, replacing any
+// existing entries for the same codes.
+func (m *Mapper) WithCodes(table map[any]codes.Code) *Mapper {
+	merged := maps.Clone(m.codes)
+	maps.Copy(merged, table)
+	return &Mapper{codes: merged, mappings: m.mappings}
+}
+
+// Returns the gRPC code for the code attached to the error with errorcat.CatchCode.
+func (m *Mapper) codeOf(err error) (codes.Code, bool) {
+	code, ok := errorcat.CodeOf(err)
+	if !ok {
+		return 0, false
+	}
+	if c, ok := code.(codes.Code); ok {
+		return c, true
+	}
+	if code == nil || !reflect.TypeOf(code).Comparable() {
+		// Can't be a map key.
+		return 0, false
+	}
+	c, ok := m.codes[code]
+	return c, ok
+}
+
+// Returns the gRPC status for an error. The status message is the error message. A nil
+// error has a nil status, which gRPC treats as OK.
+func (m *Mapper) Status(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	if code, ok := m.codeOf(err); ok {
+		return status.New(code, err.Error())
+	}
+
+	code := codes.Internal
+	for _, mapping := range m.mappings {
+		if errors.Is(err, mapping.Target) {
+			code = mapping.Code
+			break
+		}
+	}
+	return status.New(code, err.Error())
+}
+
+// Returns an annotator that attaches the gRPC status to the error. Place it at the end of
+// the annotator chain so that the status reflects the final error. gRPC servers find the
+// status with status.FromError, and the original error chain is preserved.
+func (m *Mapper) Annotator() errorcat.Annotator {
+	return func(err error) error {
+		return &statusError{err: err, status: m.Status(err)}
+	}
+}
+
+var defaultMapper = NewMapper()
+
+// Returns the gRPC status for an error using [DefaultCodes] and [DefaultMappings].
+func GRPCStatus(err error) *status.Status {
+	return defaultMapper.Status(err)
+}
+
+// Returns an annotator that attaches the gRPC status using [DefaultCodes] and
+// [DefaultMappings].
+func Annotator() errorcat.Annotator {
+	return defaultMapper.Annotator()
+}
+
+// Attaches a gRPC status to an error in the chain.
+type statusError struct {
+	err    error
+	status *status.Status
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+// Used by status.FromError.
+func (e *statusError) GRPCStatus() *status.Status {
+	return e.status
+}
@@ -0,0 +1,124 @@
+package grpccat_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mukunda.com/errorcat"
+	"go.mukunda.com/errorcat/grpccat"
+	"go.mukunda.com/errorcat/sqlcat"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errTest = errors.New("test-error")
+var errBadRequest = errors.New("bad request")
+
+// Known errors are mapped to their gRPC codes.
+func TestGRPCStatus(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{fmt.Errorf("user: %w", sqlcat.ErrNotFound), codes.NotFound},
+		{fmt.Errorf("request: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{context.Canceled, codes.Canceled},
+		{os.ErrPermission, codes.PermissionDenied},
+		{errorcat.ErrReentrant, codes.Aborted},
+		{errTest, codes.Internal},
+	} {
+		s := grpccat.GRPCStatus(tc.err)
+		assert.Equal(t, tc.code, s.Code(), tc.err.Error())
+		assert.Equal(t, tc.err.Error(), s.Message())
+	}
+
+	assert.Nil(t, grpccat.GRPCStatus(nil))
+	assert.Equal(t, codes.OK, grpccat.GRPCStatus(nil).Code())
+}
+
+// Overrides are checked before the defaults.
+func TestMapperOverrides(t *testing.T) {
+	mapper := grpccat.NewMapper(
+		grpccat.Mapping{Target: errBadRequest, Code: codes.InvalidArgument},
+		grpccat.Mapping{Target: sqlcat.ErrNotFound, Code: codes.FailedPrecondition},
+	)
+
+	assert.Equal(t, codes.InvalidArgument, mapper.Status(errBadRequest).Code())
+	assert.Equal(t, codes.FailedPrecondition, mapper.Status(sqlcat.ErrNotFound).Code())
+	assert.Equal(t, codes.Canceled, mapper.Status(context.Canceled).Code())
+	assert.Equal(t, codes.Internal, mapper.Status(errTest).Code())
+}
+
+// The annotator attaches a status that gRPC can find with status.FromError.
+func TestAnnotator(t *testing.T) {
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		sqlcat.CatchNotFound(fmt.Errorf("scan: %w", errTest), "lookup failed")
+		return nil
+	}, grpccat.Annotator())
+
+	s, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Internal, s.Code())
+	assert.Equal(t, "lookup failed: scan: test-error", s.Message())
+	assert.ErrorIs(t, err, errTest)
+
+	mapper := grpccat.NewMapper(grpccat.Mapping{Target: errBadRequest, Code: codes.InvalidArgument})
+	err = errorcat.Guard(func(ct errorcat.Context) error {
+		ct.Catch(true, fmt.Errorf("%w: id is required", errBadRequest))
+		return nil
+	}, "GetUser failed", mapper.Annotator())
+
+	s, ok = status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, s.Code())
+	assert.Equal(t, "GetUser failed: bad request: id is required", s.Message())
+}
+
+// Codes attached with CatchCode are mapped through the code table first.
+func TestCodes(t *testing.T) {
+	catchCode := func(code any, condition any) error {
+		return errorcat.Guard(func(ct errorcat.Context) error {
+			errorcat.CatchCode(condition, code, "request failed")
+			return nil
+		})
+	}
+
+	for _, tc := range []struct {
+		code any
+		want codes.Code
+	}{
+		{"bad-request", codes.InvalidArgument},
+		{400, codes.InvalidArgument},
+		{"not-found", codes.NotFound},
+		{404, codes.NotFound},
+		{"unauthenticated", codes.Unauthenticated},
+		{codes.DataLoss, codes.DataLoss},
+		{"unknown-tag", codes.Internal},
+		{[]string{"uncomparable"}, codes.Internal},
+	} {
+		s := grpccat.GRPCStatus(catchCode(tc.code, errTest))
+		assert.Equal(t, tc.want, s.Code(), tc.code)
+	}
+
+	// The code takes precedence over sentinels, which are used when no code maps.
+	err := catchCode("bad-request", sqlcat.ErrNotFound)
+	assert.Equal(t, codes.InvalidArgument, grpccat.GRPCStatus(err).Code())
+	err = catchCode("unknown-tag", sqlcat.ErrNotFound)
+	assert.Equal(t, codes.NotFound, grpccat.GRPCStatus(err).Code())
+
+	// The table is configurable.
+	mapper := grpccat.NewMapper().WithCodes(map[any]codes.Code{
+		"not-found": codes.FailedPrecondition,
+		"E1234":     codes.OutOfRange,
+	})
+	assert.Equal(t, codes.FailedPrecondition, mapper.Status(catchCode("not-found", errTest)).Code())
+	assert.Equal(t, codes.OutOfRange, mapper.Status(catchCode("E1234", errTest)).Code())
+	assert.Equal(t, codes.InvalidArgument, mapper.Status(catchCode(400, errTest)).Code())
+
+	// The defaults are unchanged.
+	assert.Equal(t, codes.Internal, grpccat.GRPCStatus(catchCode("E1234", errTest)).Code())
+}
@@ -0,0 +1,51 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// Caught by [GuardedMutex.AssertHeld] when the mutex is not locked.
+var ErrNotHeld = errors.New("mutex is not held")
+
+/*
+A GuardedMutex is a sync.Mutex that tracks whether it's held, so that code paths that
+require the lock can assert it. This helps catch "forgot to lock" bugs during development.
+
+	func (s *store) setLocked(key, value string) {
+		s.mu.AssertHeld("setLocked requires the lock")
+		s.data[key] = value
+	}
+
+Go mutexes aren't owned by a goroutine, so AssertHeld only checks that the mutex is held,
+not who holds it. Build with the `errorcat_release` tag to compile the checks out.
+*/
+type GuardedMutex struct {
+	mutex sync.Mutex
+	held  atomic.Bool
+}
+
+// Locks the mutex.
+func (m *GuardedMutex) Lock() {
+	m.mutex.Lock()
+	m.held.Store(true)
+}
+
+// Tries to lock the mutex, returning true if successful.
+func (m *GuardedMutex) TryLock() bool {
+	if m.mutex.TryLock() {
+		m.held.Store(true)
+		return true
+	}
+	return false
+}
+
+// Unlocks the mutex.
+func (m *GuardedMutex) Unlock() {
+	m.held.Store(false)
+	m.mutex.Unlock()
+}
@@ -0,0 +1,14 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+//go:build !errorcat_release
+
+package errorcat
+
+// Catches [ErrNotHeld] if the mutex is not locked. `problem` works the same as in
+// [Catch]. This is a no-op when built with the `errorcat_release` tag.
+func (m *GuardedMutex) AssertHeld(problem ...any) {
+	if !m.held.Load() {
+		Catch(ErrNotHeld, problem...)
+	}
+}
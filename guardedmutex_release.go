@@ -0,0 +1,9 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+//go:build errorcat_release
+
+package errorcat
+
+// Checks are compiled out in release builds.
+func (m *GuardedMutex) AssertHeld(problem ...any) {}
@@ -0,0 +1,36 @@
+//go:build !errorcat_release
+
+package errorcat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// AssertHeld catches when the mutex is not locked.
+func TestGuardedMutex(t *testing.T) {
+	var mu cat.GuardedMutex
+
+	err := cat.Guard(func(ct cat.Context) error {
+		mu.AssertHeld("setLocked requires the lock")
+		return nil
+	})
+	assert.Equal(t, "setLocked requires the lock: mutex is not held", err.Error())
+	assert.ErrorIs(t, err, cat.ErrNotHeld)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		mu.AssertHeld()
+		return nil
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, mu.TryLock())
+	assert.False(t, mu.TryLock())
+	assert.NotPanics(t, func() { mu.AssertHeld() })
+	mu.Unlock()
+	assert.Panics(t, func() { mu.AssertHeld() })
+}
@@ -0,0 +1,65 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Propagated by [Heartbeat.Beat] when the previous beat was too long ago.
+var ErrStalled = errors.New("heartbeat timed out")
+
+/*
+A Heartbeat detects stalls in long operations that should make steady progress, e.g., a
+processing loop. The operation calls [Heartbeat.Beat] periodically, and a watchdog flags
+the heartbeat if no beat occurs within the timeout.
+
+	hb := cat.NewHeartbeat(30 * time.Second)
+	defer hb.Stop()
+	for _, item := range items {
+		hb.Beat("processing stalled")
+		process(item)
+	}
+
+The check is cooperative. Go can't inject a panic into another goroutine, so the watchdog
+only sets a flag, and the stall is caught by the worker at its next call to Beat. A worker
+that is blocked forever is never interrupted; use a context deadline for that.
+*/
+type Heartbeat struct {
+	timeout  time.Duration
+	watchdog *time.Timer
+	stalled  atomic.Bool
+}
+
+// Creates a [Heartbeat] and starts its watchdog. The first beat is due within `timeout`.
+// Call [Heartbeat.Stop] when the operation is finished.
+func NewHeartbeat(timeout time.Duration) *Heartbeat {
+	hb := &Heartbeat{timeout: timeout}
+	hb.watchdog = time.AfterFunc(timeout, func() {
+		hb.stalled.Store(true)
+	})
+	return hb
+}
+
+// Records progress. If the watchdog flagged a stall since the last beat, this catches an
+// error wrapping [ErrStalled], annotated with `problem` like [Catch].
+func (hb *Heartbeat) Beat(problem ...any) {
+	if hb.stalled.Load() {
+		Catch(fmt.Errorf("%w: no beat within %v", ErrStalled, hb.timeout), problem...)
+	}
+	hb.watchdog.Reset(hb.timeout)
+}
+
+// Returns true if the watchdog has flagged a stall.
+func (hb *Heartbeat) Stalled() bool {
+	return hb.stalled.Load()
+}
+
+// Stops the watchdog.
+func (hb *Heartbeat) Stop() {
+	hb.watchdog.Stop()
+}
@@ -0,0 +1,39 @@
+package errorcat_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// A worker that makes steady progress isn't interrupted.
+func TestHeartbeatSteady(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		hb := cat.NewHeartbeat(200 * time.Millisecond)
+		defer hb.Stop()
+		for i := 0; i < 5; i++ {
+			hb.Beat("worker stalled")
+			time.Sleep(time.Millisecond)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+// A stalled worker is caught at its next beat.
+func TestHeartbeatStalled(t *testing.T) {
+	var hb *cat.Heartbeat
+	err := cat.Guard(func(ct cat.Context) error {
+		hb = cat.NewHeartbeat(10 * time.Millisecond)
+		defer hb.Stop()
+		hb.Beat("worker stalled")
+		time.Sleep(50 * time.Millisecond)
+		hb.Beat("worker stalled")
+		return nil
+	})
+	assert.ErrorIs(t, err, cat.ErrStalled)
+	assert.EqualError(t, err, "worker stalled: heartbeat timed out: no beat within 10ms")
+	assert.True(t, hb.Stalled())
+}
@@ -0,0 +1,99 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+type sentinelHook struct {
+	sentinel error
+	fn       func()
+}
+
+var (
+	sentinelHooksMutex sync.RWMutex
+	sentinelHooks      []sentinelHook
+)
+
+/*
+Registers a callback that is called when Catch propagates an error matching `sentinel`
+with errors.Is. This is for grabbing diagnostics the moment a known problematic error
+occurs, without leaving profiling on all the time:
+
+	cat.OnSentinelCatch(ErrRare, func() {
+		pprof.Lookup("goroutine").WriteTo(dumpFile, 1)
+	})
+
+Callbacks run synchronously on the goroutine that called Catch, before the error
+propagates. Multiple callbacks can be registered for the same sentinel, and they run in
+registration order.
+*/
+func OnSentinelCatch(sentinel error, fn func()) {
+	sentinelHooksMutex.Lock()
+	defer sentinelHooksMutex.Unlock()
+	sentinelHooks = append(sentinelHooks, sentinelHook{sentinel: sentinel, fn: fn})
+}
+
+// Calls the sentinel hooks that match the error.
+func runSentinelHooks(err error) {
+	sentinelHooksMutex.RLock()
+	hooks := sentinelHooks
+	sentinelHooksMutex.RUnlock()
+
+	for _, hook := range hooks {
+		if errors.Is(err, hook.sentinel) {
+			hook.fn()
+		}
+	}
+}
+
+var recoverHook atomic.Pointer[func(err error)]
+
+/*
+Sets a callback that is called once per [Recover] that captures an error, with the final
+error after all annotators, including the defaults from [SetDefaultAnnotators]. This is for
+metrics, e.g., counting errors by type:
+
+	cat.SetRecoverHook(func(err error) {
+		errorsTotal.WithLabelValues(fmt.Sprintf("%T", errors.Unwrap(err))).Inc()
+	})
+
+It isn't called when there is no error, or when an annotator handled the error by
+returning nil. Unlike an annotator, it can't alter the error. It runs on the recovering
+goroutine, so it must be safe for concurrent use. Passing nil removes the hook.
+*/
+func SetRecoverHook(fn func(err error)) {
+	if fn == nil {
+		recoverHook.Store(nil)
+		return
+	}
+	recoverHook.Store(&fn)
+}
+
+// Returns the annotate list for a top-level [Recover], with the default annotators and the
+// recover hook appended. Sub-recoveries, e.g., for [ExtendedContext.Sub], don't use these, so they
+// apply once.
+func recoverAnnotators(annotate []any) []any {
+	defaults := defaultAnnotators.Load()
+	hook := recoverHook.Load()
+	if defaults == nil && hook == nil {
+		return annotate
+	}
+
+	annotate = append([]any(nil), annotate...)
+	if defaults != nil {
+		annotate = append(annotate, *defaults...)
+	}
+	if hook != nil {
+		annotate = append(annotate, Finally(func(err error) {
+			if err != nil {
+				(*hook)(err)
+			}
+		}))
+	}
+	return annotate
+}
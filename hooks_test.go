@@ -0,0 +1,78 @@
+package errorcat_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// Sentinel hooks only fire for matching errors.
+func TestOnSentinelCatch(t *testing.T) {
+	errRare := errors.New("rare error")
+	var calls []string
+
+	cat.OnSentinelCatch(errRare, func() { calls = append(calls, "first") })
+	cat.OnSentinelCatch(errRare, func() { calls = append(calls, "second") })
+
+	err := cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest, "common")
+		return nil
+	})
+	assert.ErrorIs(t, err, errTest)
+	assert.Empty(t, calls)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(fmt.Errorf("wrapped: %w", errRare), "rare")
+		return nil
+	})
+	assert.ErrorIs(t, err, errRare)
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
+// The recover hook is called once per recovered error, with the final error.
+func TestSetRecoverHook(t *testing.T) {
+	var hooked []error
+	cat.SetRecoverHook(func(err error) { hooked = append(hooked, err) })
+	t.Cleanup(func() { cat.SetRecoverHook(nil) })
+
+	err := cat.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		_, done := ext.Sub("step")
+		done()
+		ct.Catch(errTest, "problem")
+		return nil
+	}, "annotated")
+	assert.EqualError(t, err, "annotated: problem: test-error")
+	assert.Equal(t, []error{err}, hooked)
+
+	// Not called on success or when an annotator handles the error.
+	assert.NoError(t, cat.Guard(func(ct cat.Context) error { return nil }))
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest)
+		return nil
+	}, func(err error) error { return nil })
+	assert.NoError(t, err)
+	assert.Len(t, hooked, 1)
+
+	// Safe for concurrent recoveries.
+	var count atomic.Int32
+	cat.SetRecoverHook(func(err error) { count.Add(1) })
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cat.Guard(func(ct cat.Context) error {
+				ct.Catch(errTest)
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(10), count.Load())
+}
@@ -0,0 +1,46 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Caught by [CatchDuplicateRequest] when a request was already seen. Map it to a status
+// like http.StatusConflict in a [Responder].
+var ErrConflict = errors.New("conflict")
+
+// Storage for idempotency keys, e.g., backed by Redis or an in-memory map. See
+// [CatchDuplicateRequest].
+type IdempotencyStore interface {
+	// Returns true if the key was recorded.
+	Seen(key string) bool
+
+	// Records the key.
+	Record(key string)
+}
+
+/*
+Catches [ErrConflict] if the idempotency key was already seen by the store. Otherwise, the
+key is recorded and execution continues. This is for API handlers that must not process
+the same request twice:
+
+	func HandlePayment(w http.ResponseWriter, r *http.Request) {
+		err := cat.Guard(func(ct cat.Context) error {
+			cat.CatchDuplicateRequest(store, r.Header.Get("Idempotency-Key"), "duplicate payment")
+			...
+		}, responder.Annotator())
+		...
+	}
+
+The check and the record are separate calls, so the store should make Record atomic with
+respect to Seen if concurrent duplicates must be rejected.
+*/
+func CatchDuplicateRequest(store IdempotencyStore, key string, problem ...any) {
+	if store.Seen(key) {
+		Catch(fmt.Errorf("%w: request %q was already processed", ErrConflict, key), problem...)
+	}
+	store.Record(key)
+}
@@ -0,0 +1,44 @@
+package errorcat_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+type memoryStore struct {
+	mutex sync.Mutex
+	keys  map[string]bool
+}
+
+func (s *memoryStore) Seen(key string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.keys[key]
+}
+
+func (s *memoryStore) Record(key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.keys[key] = true
+}
+
+// The first request with a key passes, and later ones are conflicts.
+func TestCatchDuplicateRequest(t *testing.T) {
+	store := &memoryStore{keys: map[string]bool{}}
+	handle := func(key string) error {
+		return cat.Guard(func(ct cat.Context) error {
+			cat.CatchDuplicateRequest(store, key, "duplicate payment")
+			return nil
+		})
+	}
+
+	assert.NoError(t, handle("abc"))
+	assert.NoError(t, handle("def"))
+
+	err := handle("abc")
+	assert.ErrorIs(t, err, cat.ErrConflict)
+	assert.EqualError(t, err, `duplicate payment: conflict: request "abc" was already processed`)
+}
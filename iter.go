@@ -0,0 +1,43 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+//go:build go1.23
+
+package errorcat
+
+import "iter"
+
+/*
+Guards an iteration over a sequence. `fn` is called for each item, and the first error,
+whether caught or returned, stops the iteration and is returned. `annotate` parameters
+work the same as in [Guard].
+
+	err := cat.Seq(rows.All(), func(ct cat.Context, row Row) error {
+		ct.Catch(row.Validate(), "invalid row")
+		return save(row)
+	}, "import failed")
+
+Iterators that panic on their own are also recovered by the guard.
+*/
+func Seq[T any](items iter.Seq[T], fn func(ct Context, v T) error, annotate ...any) error {
+	return guard(func(ct Context) error {
+		for v := range items {
+			if err := fn(ct, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, annotate)
+}
+
+// Same as [Seq], but for sequences of pairs, e.g., from maps.All or slices.All.
+func Seq2[K, V any](items iter.Seq2[K, V], fn func(ct Context, k K, v V) error, annotate ...any) error {
+	return guard(func(ct Context) error {
+		for k, v := range items {
+			if err := fn(ct, k, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, annotate)
+}
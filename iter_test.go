@@ -0,0 +1,65 @@
+//go:build go1.23
+
+package errorcat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// Yields the numbers from 1 to n.
+func countTo(n int) func(yield func(int) bool) {
+	return func(yield func(int) bool) {
+		for i := 1; i <= n; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// The first caught error stops the iteration.
+func TestSeq(t *testing.T) {
+	var visited []int
+	err := cat.Seq(countTo(5), func(ct cat.Context, v int) error {
+		visited = append(visited, v)
+		ct.Catch(v == 3, "item 3 is bad")
+		return nil
+	}, "iteration failed")
+	assert.EqualError(t, err, "iteration failed: item 3 is bad")
+	assert.Equal(t, []int{1, 2, 3}, visited)
+
+	// Returned errors also stop the iteration.
+	visited = nil
+	err = cat.Seq(countTo(5), func(ct cat.Context, v int) error {
+		visited = append(visited, v)
+		if v == 2 {
+			return errTest
+		}
+		return nil
+	})
+	assert.Equal(t, errTest, err)
+	assert.Equal(t, []int{1, 2}, visited)
+
+	err = cat.Seq(countTo(5), func(ct cat.Context, v int) error { return nil })
+	assert.NoError(t, err)
+}
+
+// Seq2 works the same for pairs.
+func TestSeq2(t *testing.T) {
+	pairs := func(yield func(string, error) bool) {
+		_ = yield("a", nil) && yield("b", errTest) && yield("c", nil)
+	}
+
+	var visited []string
+	err := cat.Seq2(pairs, func(ct cat.Context, k string, v error) error {
+		visited = append(visited, k)
+		ct.Catch(v, "loading "+k)
+		return nil
+	})
+	assert.EqualError(t, err, "loading b: test-error")
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, []string{"a", "b"}, visited)
+}
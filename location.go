@@ -0,0 +1,45 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"runtime"
+	"strings"
+)
+
+// Returns the name of a function in the call stack, in the form "pkg.Func". `skip` is the
+// number of frames to skip, where 0 is the caller of funcName.
+func funcName(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "unknown"
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "unknown"
+	}
+
+	// Strip the package path, e.g., "go.mukunda.com/errorcat.Catch" -> "errorcat.Catch".
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// Returns the name of the calling function in the form "pkg.Func". This is evaluated
+// eagerly, so prefer [CatchHere] over `cat.Catch(err, cat.Here())`.
+func Here() string {
+	return funcName(1)
+}
+
+// Same as [Catch], but the problem is the name of the calling function, e.g.,
+// "mypkg.LoadConfig: open config.json: no such file or directory". The name is only looked
+// up when the condition triggers, so this is cheap on the happy path.
+func CatchHere(condition any) {
+	if !triggered(condition) {
+		return
+	}
+	throw(caught(condition, []any{funcName(1)}))
+}
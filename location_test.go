@@ -0,0 +1,40 @@
+package errorcat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+func loadConfig(fail bool) (rerr error) {
+	defer cat.Recover(&rerr)
+	cat.CatchHere(fail)
+	cat.CatchHere(errTest)
+	return nil
+}
+
+// Here returns the calling function's name.
+func TestHere(t *testing.T) {
+	assert.Equal(t, "errorcat_test.TestHere", cat.Here())
+}
+
+// CatchHere prefixes errors with the calling function's name.
+func TestCatchHere(t *testing.T) {
+	err := loadConfig(false)
+	assert.Equal(t, "errorcat_test.loadConfig: test-error", err.Error())
+	assert.ErrorIs(t, err, errTest)
+
+	err = loadConfig(true)
+	assert.Equal(t, "errorcat_test.loadConfig", err.Error())
+}
+
+// The happy path is cheap and doesn't allocate.
+func TestCatchHereHappyPath(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		cat.CatchHere(nil)
+		cat.CatchHere(false)
+		cat.CatchHere(error(nil))
+	})
+	assert.Equal(t, 0.0, allocs)
+}
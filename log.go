@@ -0,0 +1,89 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	gocontext "context"
+	"errors"
+	"log/slog"
+)
+
+// Implements slog.LogValuer, so passing a [CatError] to slog emits its metadata as
+// structured fields. See [ErrorLogValue].
+func (e CatError) LogValue() slog.Value {
+	return ErrorLogValue(e.err)
+}
+
+/*
+Returns a structured log value for an error. If the error carries metadata from Errorcat,
+e.g., from [CatchSev], [CatchDoc], [CatchCode], [CatchFix], or [CatchAttrs], the value is
+a group containing the message, the cause if a problem was given (see [CauseOf]), and the
+metadata. Otherwise, it's just the message.
+
+Errors returned from guards are no longer [CatError]s, so use this to log them with their
+metadata:
+
+	slog.Error("request failed", "err", cat.ErrorLogValue(err))
+*/
+func ErrorLogValue(err error) slog.Value {
+	if err == nil {
+		return slog.StringValue("<nil>")
+	}
+
+	var attrs []slog.Attr
+
+	var se *severityError
+	if errors.As(err, &se) {
+		attrs = append(attrs, slog.String("severity", se.severity.String()))
+	}
+
+	if url, ok := DocURL(err); ok {
+		attrs = append(attrs, slog.String("doc", url))
+	}
+
+	if code, ok := CodeOf(err); ok {
+		attrs = append(attrs, slog.Any("code", code))
+	}
+
+	if fix, ok := Remediation(err); ok {
+		attrs = append(attrs, slog.String("fix", fix))
+	}
+
+	attrs = append(attrs, AttrsOf(err)...)
+
+	if len(attrs) == 0 {
+		return slog.StringValue(err.Error())
+	}
+
+	group := []slog.Attr{slog.String("message", err.Error())}
+	if ProblemOf(err) != nil {
+		group = append(group, slog.String("cause", CauseOf(err).Error()))
+	}
+	return slog.GroupValue(append(group, attrs...)...)
+}
+
+/*
+Returns an annotator that logs the error at the given level and returns it unchanged, so
+the annotator chain continues. The error is logged with [ErrorLogValue], and the stack is
+included if it was captured (see [SetCaptureStack]).
+
+	defer cat.Recover(&rerr, "handler failed", cat.LogAnnotator(logger, slog.LevelError))
+
+The position in the chain matters: annotations before it are included in the logged
+message. If `logger` is nil, the annotator does nothing.
+*/
+func LogAnnotator(logger *slog.Logger, level slog.Level) Annotator {
+	return func(err error) error {
+		if logger == nil {
+			return err
+		}
+
+		args := []any{"err", ErrorLogValue(err)}
+		if stack := StackOf(err); stack != "" {
+			args = append(args, "stack", stack)
+		}
+		logger.Log(gocontext.Background(), level, "error caught", args...)
+		return err
+	}
+}
@@ -0,0 +1,118 @@
+package errorcat_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// Returns the panic value from a Catch call.
+func recoverCatError(fn func()) (r any) {
+	defer func() {
+		r = recover()
+	}()
+	fn()
+	return nil
+}
+
+// A CatError with metadata is logged as a group.
+func TestCatErrorLogValue(t *testing.T) {
+	r := recoverCatError(func() {
+		cat.CatchAttrs(errTest, []slog.Attr{slog.String("user", "bob")}, "failed saving user")
+	})
+
+	catErr := r.(cat.CatError)
+	value := catErr.LogValue()
+	assert.Equal(t, slog.KindGroup, value.Kind())
+	assert.Equal(t, []slog.Attr{
+		slog.String("message", "failed saving user: test-error"),
+		slog.String("cause", "test-error"),
+		slog.String("user", "bob"),
+	}, value.Group())
+
+	// Metadata also works for errors that were already recovered.
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchSev(cat.SeverityWarning, errTest, "problem")
+		return nil
+	}, "outer")
+
+	value = cat.ErrorLogValue(err)
+	assert.Equal(t, []slog.Attr{
+		slog.String("message", "outer: problem: test-error"),
+		slog.String("cause", "test-error"),
+		slog.String("severity", "warning"),
+	}, value.Group())
+}
+
+// A plain CatError is logged as just the message.
+func TestCatErrorLogValuePlain(t *testing.T) {
+	r := recoverCatError(func() {
+		cat.Catch(errTest, "problem")
+	})
+
+	value := r.(cat.CatError).LogValue()
+	assert.Equal(t, slog.KindString, value.Kind())
+	assert.Equal(t, "problem: test-error", value.String())
+
+	// slog resolves the value automatically.
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Error("failed", "err", r)
+	assert.Equal(t, "level=ERROR msg=failed err=\"problem: test-error\"\n", buf.String())
+}
+
+// LogAnnotator logs the error and passes it through.
+func TestLogAnnotator(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	err := cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest, "problem")
+		return nil
+	}, "handler failed", cat.LogAnnotator(logger, slog.LevelWarn), "outer")
+
+	assert.EqualError(t, err, "outer: handler failed: problem: test-error")
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, `level=WARN msg="error caught" err="handler failed: problem: test-error"`+"\n",
+		buf.String())
+
+	// Levels below the handler's minimum aren't logged.
+	buf.Reset()
+	err = cat.Guard(func(ct cat.Context) error {
+		return errTest
+	}, cat.LogAnnotator(logger, slog.LevelDebug))
+	assert.Equal(t, errTest, err)
+	assert.Empty(t, buf.String())
+
+	// Captured stacks are included.
+	cat.SetCaptureStack(true)
+	t.Cleanup(func() { cat.SetCaptureStack(false) })
+	_ = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest)
+		return nil
+	}, cat.LogAnnotator(logger, slog.LevelError))
+	assert.Contains(t, buf.String(), "stack=\"go.mukunda.com/errorcat_test.TestLogAnnotator")
+
+	// A nil logger does nothing.
+	err = cat.Guard(func(ct cat.Context) error {
+		return errTest
+	}, cat.LogAnnotator(nil, slog.LevelError))
+	assert.Equal(t, errTest, err)
+}
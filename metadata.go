@@ -0,0 +1,120 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import "errors"
+
+// Attaches a documentation URL to an error in the chain.
+type docError struct {
+	err error
+	url string
+}
+
+func (e *docError) Error() string {
+	return e.err.Error()
+}
+
+func (e *docError) Unwrap() error {
+	return e.err
+}
+
+// Same as [Catch], but the propagated error carries a URL to documentation that explains
+// the error, e.g., for a CLI tool to print "see <url> for help". The URL doesn't affect
+// the error message and can be read with [DocURL] after the error is recovered.
+func CatchDoc(condition any, docURL string, problem ...any) {
+	if err := caught(condition, problem); err != nil {
+		throw(&docError{err: err, url: docURL})
+	}
+}
+
+// Returns the documentation URL attached to the error by [CatchDoc], if any.
+func DocURL(err error) (string, bool) {
+	var de *docError
+	if errors.As(err, &de) {
+		return de.url, true
+	}
+	return "", false
+}
+
+// Attaches a remediation hint to an error in the chain.
+type fixError struct {
+	err         error
+	remediation string
+}
+
+func (e *fixError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fixError) Unwrap() error {
+	return e.err
+}
+
+/*
+Same as [Catch], but the propagated error carries a remediation hint that tells the user
+how to fix the problem. This keeps the "what" (the error message) separate from the "how
+to fix it", e.g., for a CLI tool:
+
+	cat.CatchFix(err, "run `mytool init` to create a config file", "config not found")
+	...
+	fmt.Printf("Error: %v\n", err)
+	if fix, ok := cat.Remediation(err); ok {
+		fmt.Printf("To fix: %s\n", fix)
+	}
+
+The hint doesn't affect the error message and can be read with [Remediation] after the
+error is recovered.
+*/
+func CatchFix(condition any, remediation string, problem ...any) {
+	if err := caught(condition, problem); err != nil {
+		throw(&fixError{err: err, remediation: remediation})
+	}
+}
+
+// Returns the remediation hint attached to the error by [CatchFix], if any.
+func Remediation(err error) (string, bool) {
+	var fe *fixError
+	if errors.As(err, &fe) {
+		return fe.remediation, true
+	}
+	return "", false
+}
+
+// Attaches a code to an error in the chain.
+type codeError struct {
+	err  error
+	code any
+}
+
+func (e *codeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codeError) Unwrap() error {
+	return e.err
+}
+
+// Same as [Catch], but the propagated error carries a code, e.g., an HTTP status, an exit
+// code, or a string identifier like "E1234". The code doesn't affect the error message and
+// can be read with [CodeOf] after the error is recovered.
+func CatchCode(condition any, code any, problem ...any) {
+	if err := caught(condition, problem); err != nil {
+		throw(&codeError{err: err, code: code})
+	}
+}
+
+// Returns the code attached to the error by [CatchCode], if any. If codes were attached
+// more than once, e.g., by nested catches, the outermost one is returned.
+func CodeOf(err error) (any, bool) {
+	var ce *codeError
+	if errors.As(err, &ce) {
+		return ce.code, true
+	}
+	return nil, false
+}
+
+// Returns the code attached by [CatchCode], if any.
+func (e CatError) Code() (any, bool) {
+	return CodeOf(e.err)
+}
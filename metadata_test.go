@@ -0,0 +1,79 @@
+package errorcat_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// Documentation URLs survive annotation and can be read after recovery.
+func TestCatchDoc(t *testing.T) {
+	const url = "https://example.com/errors/E1234"
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchDoc(false, url, "not triggered")
+		cat.CatchDoc(errTest, url, "config is invalid")
+		return nil
+	}, "outer", func(err error) error {
+		return fmt.Errorf("wrapped: %w", err)
+	})
+
+	assert.Equal(t, "wrapped: outer: config is invalid: test-error", err.Error())
+	assert.ErrorIs(t, err, errTest)
+
+	docURL, ok := cat.DocURL(err)
+	assert.True(t, ok)
+	assert.Equal(t, url, docURL)
+
+	_, ok = cat.DocURL(errTest)
+	assert.False(t, ok)
+}
+
+// Remediation hints are independent of the message and survive annotation.
+func TestCatchFix(t *testing.T) {
+	const fix = "run `mytool init` to create a config file"
+
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchFix(nil, fix, "not triggered")
+		cat.CatchFix(errTest, fix, "config not found")
+		return nil
+	}, "loading failed")
+
+	assert.Equal(t, "loading failed: config not found: test-error", err.Error())
+	assert.ErrorIs(t, err, errTest)
+
+	remediation, ok := cat.Remediation(err)
+	assert.True(t, ok)
+	assert.Equal(t, fix, remediation)
+
+	_, ok = cat.Remediation(errTest)
+	assert.False(t, ok)
+}
+
+// Codes survive recovery and annotation.
+func TestCatchCode(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchCode(false, http.StatusBadRequest, "not triggered")
+		cat.CatchCode(true, http.StatusBadRequest, "name is required")
+		return nil
+	}, "invalid request")
+
+	assert.EqualError(t, err, "invalid request: name is required")
+	code, ok := cat.CodeOf(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	_, ok = cat.CodeOf(errTest)
+	assert.False(t, ok)
+
+	// The code is also available on the CatError before recovery.
+	defer func() {
+		code, ok := recover().(cat.CatError).Code()
+		assert.True(t, ok)
+		assert.Equal(t, "E1234", code)
+	}()
+	cat.CatchCode(errTest, "E1234")
+}
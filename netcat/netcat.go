@@ -0,0 +1,80 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+/*
+This package provides Errorcat helpers for validating network addresses, e.g., listen and
+connect addresses read at startup.
+
+	func loadConfig() (cfg Config, rerr error) {
+		defer errorcat.Recover(&rerr, "invalid configuration")
+
+		cfg.ListenAddr = netcat.CatchAddr(os.Getenv("LISTEN_ADDR"), "LISTEN_ADDR")
+		return cfg, nil
+	}
+
+The core [errorcat.Context] interface can't be extended from a subpackage, so there are no
+Context methods for these. The functions work the same inside of a [errorcat.Guard].
+*/
+package netcat
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"go.mukunda.com/errorcat"
+)
+
+// Caught when an address is malformed.
+var ErrInvalidAddr = errors.New("invalid address")
+
+// Caught when a port is not a number in the range 1-65535. Errors for addresses with a bad
+// port are tagged with both this and [ErrInvalidAddr].
+var ErrInvalidPort = errors.New("invalid port")
+
+// Returns an error if the port is out of range.
+func checkPort(n int) error {
+	if n < 1 || n > 65535 {
+		return fmt.Errorf("%w: %d is out of range 1-65535", ErrInvalidPort, n)
+	}
+	return nil
+}
+
+// Catches [ErrInvalidPort] if `n` is not in the range 1-65535. `problem` works the same as
+// in [errorcat.Catch].
+func CatchPort(n int, problem ...any) {
+	errorcat.Catch(checkPort(n), problem...)
+}
+
+/*
+Validates a "host:port" address and returns it normalized, e.g., "localhost:080" becomes
+"localhost:80". The host may be empty, as in ":8080" for listening on all interfaces, and
+IPv6 hosts must be bracketed, as in "[::1]:8080". The port must be numeric.
+
+If the address is malformed, [ErrInvalidAddr] is caught with a message saying what's wrong,
+e.g., a missing port. `problem` works the same as in [errorcat.Catch].
+*/
+func CatchAddr(addr string, problem ...any) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		var ae *net.AddrError
+		if errors.As(err, &ae) {
+			// Avoid repeating the address, which is in our own message.
+			err = errors.New(ae.Err)
+		}
+		errorcat.Catch(fmt.Errorf("%w %q: %w", ErrInvalidAddr, addr, err), problem...)
+	}
+
+	n, err := strconv.Atoi(port)
+	if err != nil {
+		err = fmt.Errorf("%w: %q is not a number", ErrInvalidPort, port)
+	} else {
+		err = checkPort(n)
+	}
+	if err != nil {
+		errorcat.Catch(fmt.Errorf("%w %q: %w", ErrInvalidAddr, addr, err), problem...)
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(n))
+}
@@ -0,0 +1,76 @@
+package netcat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mukunda.com/errorcat"
+	"go.mukunda.com/errorcat/netcat"
+)
+
+// Valid addresses are returned normalized.
+func TestCatchAddrValid(t *testing.T) {
+	cases := map[string]string{
+		"localhost:8080":   "localhost:8080",
+		":8080":            ":8080",
+		"localhost:080":    "localhost:80",
+		"[::1]:443":        "[::1]:443",
+		"10.0.0.1:65535":   "10.0.0.1:65535",
+		"example.com:1234": "example.com:1234",
+	}
+	for addr, expected := range cases {
+		var result string
+		err := errorcat.Guard(func(ct errorcat.Context) error {
+			result = netcat.CatchAddr(addr)
+			return nil
+		})
+		assert.NoError(t, err, addr)
+		assert.Equal(t, expected, result)
+	}
+}
+
+// Malformed addresses are caught with a message saying what's wrong.
+func TestCatchAddrInvalid(t *testing.T) {
+	cases := map[string]string{
+		"localhost":       `listen: invalid address "localhost": missing port in address`,
+		"":                `listen: invalid address "": missing port in address`,
+		"::1:80":          `listen: invalid address "::1:80": too many colons in address`,
+		"localhost:http":  `listen: invalid address "localhost:http": invalid port: "http" is not a number`,
+		"localhost:0":     `listen: invalid address "localhost:0": invalid port: 0 is out of range 1-65535`,
+		"localhost:70000": `listen: invalid address "localhost:70000": invalid port: 70000 is out of range 1-65535`,
+	}
+	for addr, expected := range cases {
+		err := errorcat.Guard(func(ct errorcat.Context) error {
+			netcat.CatchAddr(addr, "listen")
+			return nil
+		})
+		assert.EqualError(t, err, expected)
+		assert.ErrorIs(t, err, netcat.ErrInvalidAddr)
+	}
+
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		netcat.CatchAddr("localhost:0")
+		return nil
+	})
+	assert.ErrorIs(t, err, netcat.ErrInvalidPort)
+}
+
+// Ports must be in the range 1-65535.
+func TestCatchPort(t *testing.T) {
+	for _, port := range []int{1, 80, 65535} {
+		err := errorcat.Guard(func(ct errorcat.Context) error {
+			netcat.CatchPort(port)
+			return nil
+		})
+		assert.NoError(t, err)
+	}
+
+	for _, port := range []int{-1, 0, 65536} {
+		err := errorcat.Guard(func(ct errorcat.Context) error {
+			netcat.CatchPort(port, "bad PORT")
+			return nil
+		})
+		assert.ErrorIs(t, err, netcat.ErrInvalidPort)
+		assert.Contains(t, err.Error(), "bad PORT: invalid port: ")
+	}
+}
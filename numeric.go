@@ -0,0 +1,54 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"fmt"
+	"math"
+)
+
+// Catches if `v` is not a valid ratio, i.e., NaN, infinite, or outside of [0, 1].
+// Otherwise, `v` is returned. This is for validating computed probabilities and rates
+// before using them.
+func CatchRatio(v float64, problem ...any) float64 {
+	// NaN fails both comparisons, so it's caught here as well.
+	if !(v >= 0 && v <= 1) {
+		Catch(fmt.Errorf("invalid ratio %v, expected a value in [0, 1]", v), problem...)
+	}
+	return v
+}
+
+// Returns a description of why `v` is not finite, or "" if it is.
+func notFinite(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return ""
+}
+
+// Catches if `v` is NaN or infinite, with a message naming which, e.g., "value is NaN".
+// Otherwise, `v` is returned. This stops NaN and Inf from silently corrupting the results
+// of further calculations.
+func CatchFinite(v float64, problem ...any) float64 {
+	if reason := notFinite(v); reason != "" {
+		Catch(fmt.Errorf("value is %s", reason), problem...)
+	}
+	return v
+}
+
+// Same as [CatchFinite], but checks every value in a slice, e.g., a vector. The message
+// names the first bad value, e.g., "value at index 2 is +Inf". The slice is returned.
+func CatchAllFinite(vs []float64, problem ...any) []float64 {
+	for i, v := range vs {
+		if reason := notFinite(v); reason != "" {
+			Catch(fmt.Errorf("value at index %d is %s", i, reason), problem...)
+		}
+	}
+	return vs
+}
@@ -0,0 +1,70 @@
+package errorcat_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// CatchRatio rejects NaN, infinities, and values outside of [0, 1].
+func TestCatchRatio(t *testing.T) {
+	for _, tc := range []struct {
+		v       float64
+		message string
+	}{
+		{math.NaN(), "bad rate: invalid ratio NaN, expected a value in [0, 1]"},
+		{math.Inf(1), "bad rate: invalid ratio +Inf, expected a value in [0, 1]"},
+		{math.Inf(-1), "bad rate: invalid ratio -Inf, expected a value in [0, 1]"},
+		{-0.1, "bad rate: invalid ratio -0.1, expected a value in [0, 1]"},
+		{1.5, "bad rate: invalid ratio 1.5, expected a value in [0, 1]"},
+	} {
+		err := cat.Guard(func(ct cat.Context) error {
+			cat.CatchRatio(tc.v, "bad rate")
+			return nil
+		})
+		assert.EqualError(t, err, tc.message)
+	}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		assert.Equal(t, 0.0, cat.CatchRatio(0))
+		assert.Equal(t, 0.25, cat.CatchRatio(0.25))
+		assert.Equal(t, 1.0, cat.CatchRatio(1))
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+// CatchFinite names the non-finite condition.
+func TestCatchFinite(t *testing.T) {
+	for _, tc := range []struct {
+		v       float64
+		message string
+	}{
+		{math.NaN(), "bad result: value is NaN"},
+		{math.Inf(1), "bad result: value is +Inf"},
+		{math.Inf(-1), "bad result: value is -Inf"},
+	} {
+		err := cat.Guard(func(ct cat.Context) error {
+			cat.CatchFinite(tc.v, "bad result")
+			return nil
+		})
+		assert.EqualError(t, err, tc.message)
+	}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		assert.Equal(t, 1.5, cat.CatchFinite(1.5))
+		assert.Equal(t, -math.MaxFloat64, cat.CatchFinite(-math.MaxFloat64))
+		assert.Equal(t, []float64{1, 2}, cat.CatchAllFinite([]float64{1, 2}))
+		cat.CatchAllFinite(nil)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.CatchAllFinite([]float64{1, 2, math.Inf(1), math.NaN()}, "bad vector")
+		return nil
+	})
+	assert.EqualError(t, err, "bad vector: value at index 2 is +Inf")
+}
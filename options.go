@@ -0,0 +1,201 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+var panicPassthrough atomic.Bool
+
+// When enabled, [Recover] re-panics real panics (anything not propagated by Catch) instead
+// of capturing them. This is intended for test builds, so that bugs crash loudly with
+// their full stack trace, while production builds capture them. OnPanic callbacks still
+// run before the re-panic, but annotators don't.
+//
+//	func TestMain(m *testing.M) {
+//		cat.SetPanicPassthrough(true)
+//		os.Exit(m.Run())
+//	}
+func SetPanicPassthrough(enabled bool) {
+	panicPassthrough.Store(enabled)
+}
+
+// Controls the order of the problem and the condition in messages from Catch.
+type WrapOrder int32
+
+const (
+	// The problem comes first, e.g., "couldn't write to file: io: read/write on closed
+	// pipe". This is the default.
+	ProblemFirst WrapOrder = iota
+
+	// The condition comes first, e.g., "io: read/write on closed pipe: couldn't write to
+	// file".
+	ConditionFirst
+)
+
+var wrapOrder atomic.Int32
+
+// Sets the order of the problem and the condition in messages from Catch when the
+// condition is an error. This only affects the message; both are still found by
+// errors.Is when they are errors.
+func SetWrapOrder(order WrapOrder) {
+	wrapOrder.Store(int32(order))
+}
+
+var requireProblem atomic.Bool
+
+// When enabled, calling Catch with a boolean condition and no problem is treated as a
+// programming error: it panics with [ErrBadCatch] instead of propagating [ErrUnknown].
+// The panic is not a caught error, so it crashes like any other bug when
+// [SetPanicPassthrough] is enabled. This is intended for development and test builds.
+func SetRequireProblem(enabled bool) {
+	requireProblem.Store(enabled)
+}
+
+var errorFactory atomic.Pointer[func(msg string, cause error) error]
+
+/*
+Sets a constructor for the errors that Errorcat creates from string problems, so that they
+use your own error type, e.g., one that implements your logging or serialization
+interfaces. The factory is used when:
+
+  - Catch is given a boolean condition and a non-error problem. `cause` is nil.
+  - Catch is given an error condition and a non-error problem. `cause` is the condition.
+  - Recover is given a string annotation. `cause` is the error being annotated.
+
+The factory is responsible for the message format, so [SetWrapOrder] doesn't apply to
+errors it creates. If it returns nil, the default error is used. Pass nil to restore the
+default behavior.
+*/
+func SetErrorFactory(factory func(msg string, cause error) error) {
+	if factory == nil {
+		errorFactory.Store(nil)
+		return
+	}
+	errorFactory.Store(&factory)
+}
+
+// Creates an error with the factory from [SetErrorFactory]. Returns false if there is no
+// factory or it returned nil.
+func factoryError(msg string, cause error) (error, bool) {
+	factory := errorFactory.Load()
+	if factory == nil {
+		return nil, false
+	}
+	err := (*factory)(msg, cause)
+	return err, err != nil
+}
+
+type defaultProblem struct {
+	sentinel error
+	problem  string
+}
+
+var (
+	defaultProblemsMutex sync.RWMutex
+	defaultProblems      []defaultProblem
+)
+
+/*
+Registers a default problem for errors that match `sentinel` with errors.Is. When Catch is
+given a matching error condition without a problem, the registered problem is used. This
+centralizes the wording for known errors:
+
+	cat.SetDefaultProblem(context.DeadlineExceeded, "the operation timed out")
+	...
+	cat.Catch(err) // "the operation timed out: context deadline exceeded"
+
+A problem given at the call site overrides the default. If an error matches more than one
+sentinel, the first registered one is used. Registering a sentinel again replaces its
+problem, and an empty problem removes it.
+*/
+func SetDefaultProblem(sentinel error, problem string) {
+	defaultProblemsMutex.Lock()
+	defer defaultProblemsMutex.Unlock()
+
+	for i := range defaultProblems {
+		if defaultProblems[i].sentinel == sentinel {
+			if problem == "" {
+				defaultProblems = append(defaultProblems[:i], defaultProblems[i+1:]...)
+			} else {
+				defaultProblems[i].problem = problem
+			}
+			return
+		}
+	}
+	if problem != "" {
+		defaultProblems = append(defaultProblems, defaultProblem{sentinel, problem})
+	}
+}
+
+// Marks an error that a default problem was applied to, so that it isn't applied again
+// when the error is caught again, e.g., by an outer guard.
+type defaultedError struct {
+	err error
+}
+
+func (e *defaultedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *defaultedError) Unwrap() error {
+	return e.err
+}
+
+// Returns the default problem for a Catch condition, if it's an error that a default
+// problem is registered for and hasn't been applied to yet.
+func defaultProblemOf(condition any) (string, bool) {
+	err, ok := condition.(error)
+	if !ok || isNil(err) {
+		return "", false
+	}
+	var de *defaultedError
+	if errors.As(err, &de) {
+		return "", false
+	}
+	return defaultProblemFor(err)
+}
+
+// Returns the default problem registered for the error, if any.
+func defaultProblemFor(err error) (string, bool) {
+	defaultProblemsMutex.RLock()
+	defer defaultProblemsMutex.RUnlock()
+
+	for _, dp := range defaultProblems {
+		if errors.Is(err, dp.sentinel) {
+			return dp.problem, true
+		}
+	}
+	return "", false
+}
+
+var defaultAnnotators atomic.Pointer[[]any]
+
+/*
+Installs annotators that are applied on every [Recover], after the annotate arguments of
+the call. This is a global error sink, e.g., for logging and metrics, without repeating
+the same annotators at every guard:
+
+	cat.SetDefaultAnnotators(cat.LogAnnotator(logger, slog.LevelError))
+
+If a per-call annotator handles the error by returning nil, the chain is broken and the
+defaults aren't applied. [SummaryAnnotator] and [Finally] entries work the same way as in
+the annotate list. Calling this again replaces the defaults.
+*/
+func SetDefaultAnnotators(annotate ...any) {
+	if len(annotate) == 0 {
+		defaultAnnotators.Store(nil)
+		return
+	}
+	annotate = append([]any(nil), annotate...)
+	defaultAnnotators.Store(&annotate)
+}
+
+// Removes the annotators installed by [SetDefaultAnnotators].
+func ClearDefaultAnnotators() {
+	defaultAnnotators.Store(nil)
+}
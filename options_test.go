@@ -0,0 +1,234 @@
+package errorcat_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// With passthrough enabled, real panics propagate while caught errors are still captured.
+func TestPanicPassthrough(t *testing.T) {
+	cat.SetPanicPassthrough(true)
+	t.Cleanup(func() { cat.SetPanicPassthrough(false) })
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = cat.Guard(func(ct cat.Context) error {
+			panic("boom")
+		})
+	})
+
+	err := cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest)
+		return nil
+	})
+	assert.Equal(t, errTest, err)
+
+	// With passthrough disabled, real panics are captured.
+	cat.SetPanicPassthrough(false)
+	err = cat.Guard(func(ct cat.Context) error {
+		panic("boom")
+	})
+	assert.EqualError(t, err, "boom")
+}
+
+// The wrap order changes the message but not errors.Is.
+func TestSetWrapOrder(t *testing.T) {
+	t.Cleanup(func() { cat.SetWrapOrder(cat.ProblemFirst) })
+
+	catch := func(problem any) error {
+		return cat.Guard(func(ct cat.Context) error {
+			ct.Catch(errTest, problem)
+			return nil
+		})
+	}
+
+	err := catch(errTest2)
+	assert.EqualError(t, err, "test-error2: test-error")
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, errTest2)
+	assert.EqualError(t, catch("problem"), "problem: test-error")
+
+	cat.SetWrapOrder(cat.ConditionFirst)
+
+	err = catch(errTest2)
+	assert.EqualError(t, err, "test-error: test-error2")
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, errTest2)
+
+	err = catch("problem")
+	assert.EqualError(t, err, "test-error: problem")
+	assert.ErrorIs(t, err, errTest)
+}
+
+// In strict mode, boolean catches without a problem are programming errors.
+func TestSetRequireProblem(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		ct.Catch(true)
+		return nil
+	})
+	assert.ErrorIs(t, err, cat.ErrUnknown)
+
+	cat.SetRequireProblem(true)
+	t.Cleanup(func() { cat.SetRequireProblem(false) })
+
+	assert.PanicsWithError(t, "bad catch usage: a problem is required for boolean conditions", func() {
+		cat.Catch(true)
+	})
+
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(true, "msg")
+		return nil
+	})
+	assert.EqualError(t, err, "msg")
+
+	// Error conditions don't need a problem.
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest)
+		return nil
+	})
+	assert.Equal(t, errTest, err)
+}
+
+type domainError struct {
+	msg   string
+	cause error
+}
+
+func (e *domainError) Error() string {
+	if e.cause == nil {
+		return "[domain] " + e.msg
+	}
+	return "[domain] " + e.msg + ": " + e.cause.Error()
+}
+
+func (e *domainError) Unwrap() error {
+	return e.cause
+}
+
+// The error factory creates the errors for string problems and annotations.
+func TestSetErrorFactory(t *testing.T) {
+	calls := 0
+	cat.SetErrorFactory(func(msg string, cause error) error {
+		calls++
+		return &domainError{msg: msg, cause: cause}
+	})
+	t.Cleanup(func() { cat.SetErrorFactory(nil) })
+
+	err := cat.Guard(func(ct cat.Context) error {
+		ct.Catch(true, "bad condition")
+		return nil
+	})
+	var de *domainError
+	assert.ErrorAs(t, err, &de)
+	assert.EqualError(t, err, "[domain] bad condition")
+
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest, "load failed")
+		return nil
+	}, "outer")
+	assert.ErrorAs(t, err, &de)
+	assert.Equal(t, "outer", de.msg)
+	assert.EqualError(t, err, "[domain] outer: [domain] load failed: test-error")
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, 3, calls)
+
+	// Error problems don't use the factory.
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(true, errTest)
+		return nil
+	})
+	assert.Equal(t, errTest, err)
+	assert.Equal(t, 3, calls)
+
+	cat.SetErrorFactory(nil)
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest, "load failed")
+		return nil
+	})
+	assert.EqualError(t, err, "load failed: test-error")
+}
+
+// Matching errors without a call-site problem use the registered default.
+func TestSetDefaultProblem(t *testing.T) {
+	cat.SetDefaultProblem(context.DeadlineExceeded, "the operation timed out")
+	t.Cleanup(func() { cat.SetDefaultProblem(context.DeadlineExceeded, "") })
+
+	catch := func(condition any, problem ...any) error {
+		return cat.Guard(func(ct cat.Context) error {
+			ct.Catch(condition, problem...)
+			return nil
+		})
+	}
+
+	err := catch(fmt.Errorf("query: %w", context.DeadlineExceeded))
+	assert.EqualError(t, err, "the operation timed out: query: context deadline exceeded")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	err = catch(context.DeadlineExceeded, "loading failed")
+	assert.EqualError(t, err, "loading failed: context deadline exceeded")
+
+	assert.Equal(t, errTest, catch(errTest))
+
+	// The default is applied once, even if the error is caught again by an outer guard.
+	err = cat.Guard(func(ct cat.Context) error {
+		libErr := catch(context.DeadlineExceeded)
+		ct.Catch(libErr)
+		return nil
+	})
+	assert.EqualError(t, err, "the operation timed out: context deadline exceeded")
+
+	cat.SetDefaultProblem(context.DeadlineExceeded, "")
+	assert.Equal(t, context.DeadlineExceeded, catch(context.DeadlineExceeded))
+}
+
+// Default annotators are applied after the per-call annotators on every Recover.
+func TestSetDefaultAnnotators(t *testing.T) {
+	t.Cleanup(cat.ClearDefaultAnnotators)
+
+	var sunk []error
+	cat.SetDefaultAnnotators("app", func(err error) error {
+		sunk = append(sunk, err)
+		return err
+	})
+
+	err := cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest, "problem")
+		return nil
+	}, "call")
+	assert.EqualError(t, err, "app: call: problem: test-error")
+	assert.Len(t, sunk, 1)
+
+	// No error, nothing to annotate.
+	assert.NoError(t, cat.Guard(func(ct cat.Context) error { return nil }))
+	assert.Len(t, sunk, 1)
+
+	// A per-call handler breaks the chain before the defaults.
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest)
+		return nil
+	}, func(err error) error { return nil })
+	assert.NoError(t, err)
+	assert.Len(t, sunk, 1)
+
+	// Sub-contexts are annotated only once, by the parent.
+	err = cat.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		_, done := ext.Sub("step")
+		done()
+		ct.Catch(errTest)
+		return nil
+	})
+	assert.EqualError(t, err, "app: test-error")
+	assert.Len(t, sunk, 2)
+
+	cat.ClearDefaultAnnotators()
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest)
+		return nil
+	})
+	assert.Equal(t, errTest, err)
+	assert.Len(t, sunk, 2)
+}
@@ -0,0 +1,16 @@
+module go.mukunda.com/errorcat/otelcat
+
+go 1.21
+
+require (
+	github.com/stretchr/testify v1.10.0
+	go.mukunda.com/errorcat v0.2.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,35 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+/*
+This package connects Errorcat guards to OpenTelemetry tracing. It lives in its own module
+so that the core package doesn't depend on OpenTelemetry.
+
+	func HandleRequest(ctx context.Context) error {
+		ctx, span := tracer.Start(ctx, "HandleRequest")
+		defer span.End()
+
+		return errorcat.Guard(func(ct errorcat.Context) error {
+			...
+		}, otelcat.SpanAnnotator(span), "request failed")
+	}
+*/
+package otelcat
+
+import (
+	"go.mukunda.com/errorcat"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Returns an annotator that records the caught error on the given span and marks the
+// span status as an error. The error is passed through unchanged, so this can be placed
+// anywhere in the annotator chain. The span will see the error as it is at that point in
+// the chain.
+func SpanAnnotator(span trace.Span) errorcat.Annotator {
+	return func(err error) error {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+}
@@ -0,0 +1,69 @@
+package otelcat_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mukunda.com/errorcat"
+	"go.mukunda.com/errorcat/otelcat"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// Records the calls that the annotator makes. Everything else is handled by the
+// embedded noop span.
+type mockSpan struct {
+	noop.Span
+	recorded    []error
+	statusCode  codes.Code
+	statusDesc  string
+	statusCalls int
+}
+
+func (s *mockSpan) RecordError(err error, options ...trace.EventOption) {
+	s.recorded = append(s.recorded, err)
+}
+
+func (s *mockSpan) SetStatus(code codes.Code, description string) {
+	s.statusCode = code
+	s.statusDesc = description
+	s.statusCalls++
+}
+
+var errTest = errors.New("test-error")
+
+// The span annotator records the error on the span and passes it through unchanged.
+func TestSpanAnnotator(t *testing.T) {
+	span := &mockSpan{}
+
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		ct.Catch(errTest, "problem")
+		return nil
+	}, otelcat.SpanAnnotator(span), "outer")
+
+	assert.Equal(t, "outer: problem: test-error", err.Error())
+	assert.ErrorIs(t, err, errTest)
+
+	if assert.Len(t, span.recorded, 1) {
+		assert.Equal(t, "problem: test-error", span.recorded[0].Error())
+		assert.ErrorIs(t, span.recorded[0], errTest)
+	}
+	assert.Equal(t, 1, span.statusCalls)
+	assert.Equal(t, codes.Error, span.statusCode)
+	assert.Equal(t, "problem: test-error", span.statusDesc)
+}
+
+// Nothing is recorded when the guard succeeds.
+func TestSpanAnnotatorNoError(t *testing.T) {
+	span := &mockSpan{}
+
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		return nil
+	}, otelcat.SpanAnnotator(span))
+
+	assert.NoError(t, err)
+	assert.Empty(t, span.recorded)
+	assert.Equal(t, 0, span.statusCalls)
+}
@@ -0,0 +1,31 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import "fmt"
+
+/*
+Calls `fn` and prefixes errors propagated by Catch out of it. This avoids repeating
+identifiers in every Catch call, e.g.:
+
+	cat.WithPrefix("request "+requestID, func() {
+		handleRequest(req)
+	})
+
+The prefix is applied once, as the error leaves `fn`, so errors recovered by a guard
+inside of `fn` aren't prefixed unless they're caught again. Nested prefixes are combined,
+outermost first. See also [ExtendedContext.SetPrefix].
+*/
+func WithPrefix(prefix string, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			if ce, ok := r.(CatError); ok {
+				ce.err = fmt.Errorf("%s: %w", prefix, ce.err)
+				r = ce
+			}
+			panic(r)
+		}
+	}()
+	fn()
+}
@@ -0,0 +1,102 @@
+package errorcat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+func loadUser() {
+	cat.Catch(errTest, "failed loading user")
+}
+
+// Catches within WithPrefix carry the prefix, and those outside don't.
+func TestWithPrefix(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.WithPrefix("request 123", func() {
+			cat.WithPrefix("step 2", loadUser)
+		})
+		return nil
+	})
+	assert.EqualError(t, err, "request 123: step 2: failed loading user: test-error")
+	assert.ErrorIs(t, err, errTest)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		loadUser()
+		return nil
+	})
+	assert.EqualError(t, err, "failed loading user: test-error")
+
+	// Real panics pass through unchanged.
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.WithPrefix("request 123", func() { panic("boom") })
+		return nil
+	})
+	assert.EqualError(t, err, "boom")
+}
+
+// An error from a nested guard is prefixed once when it's caught again.
+func TestWithPrefixNested(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.WithPrefix("p", func() {
+			libErr := cat.Guard(func(ct cat.Context) error {
+				ct.Catch(errTest, "lib write")
+				return nil
+			})
+			cat.Catch(libErr, "outer")
+		})
+		return nil
+	})
+	assert.EqualError(t, err, "p: outer: lib write: test-error")
+}
+
+// Context prefixes apply to catches recovered by the context.
+func TestContextSetPrefix(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		ext.SetPrefix("request 123")
+		ext.SetPrefix("request 456")
+		loadUser()
+		return nil
+	})
+	assert.EqualError(t, err, "request 456: failed loading user: test-error")
+
+	err = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest, "failed loading user")
+		return nil
+	})
+	assert.EqualError(t, err, "failed loading user: test-error")
+
+	// Returned errors aren't prefixed.
+	err = cat.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		ext.SetPrefix("request 123")
+		return errTest
+	})
+	assert.Equal(t, errTest, err)
+
+	// Other guards are not affected.
+	err = cat.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		ext.SetPrefix("request 123")
+		return <-cat.Go(func(ct cat.Context) error {
+			loadUser()
+			return nil
+		})
+	})
+	assert.Equal(t, "failed loading user: test-error", err.Error())
+
+	// Errors from nested guards are prefixed once.
+	err = cat.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		ext.SetPrefix("req-1")
+		libErr := cat.Guard(func(ct cat.Context) error {
+			ct.Catch(errTest, "lib write")
+			return nil
+		})
+		ct.Catch(libErr, "handler")
+		return nil
+	})
+	assert.EqualError(t, err, "req-1: handler: lib write: test-error")
+}
@@ -0,0 +1,85 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// A record of an error propagated by Catch, kept by the recorder. See [SetRecorder].
+type CaughtRecord struct {
+	// When the error was caught.
+	Time time.Time
+
+	// The error that was propagated.
+	Err error
+
+	// Stack trace of the goroutine at the Catch site.
+	Stack string
+}
+
+// A ring buffer of recent catches.
+type recorder struct {
+	mutex   sync.Mutex
+	records []CaughtRecord
+	next    int
+	full    bool
+}
+
+var activeRecorder atomic.Pointer[recorder]
+
+/*
+Enables the catch recorder, which keeps the last `size` caught errors process-wide along
+with their timestamps and stack traces. This is a flight recorder for debugging
+intermittent failures: when something goes wrong, dump [RecentCatches] for context.
+
+A size of zero or less disables the recorder. Changing the size discards existing
+records. Capturing stack traces is not free, so only enable this when needed.
+*/
+func SetRecorder(size int) {
+	if size <= 0 {
+		activeRecorder.Store(nil)
+		return
+	}
+	activeRecorder.Store(&recorder{records: make([]CaughtRecord, size)})
+}
+
+// Returns the recorded catches from oldest to newest, or nil if the recorder is not
+// enabled.
+func RecentCatches() []CaughtRecord {
+	r := activeRecorder.Load()
+	if r == nil {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.full {
+		return append([]CaughtRecord{}, r.records[:r.next]...)
+	}
+	return append(append([]CaughtRecord{}, r.records[r.next:]...), r.records[:r.next]...)
+}
+
+// Adds a record if the recorder is enabled.
+func record(err error) {
+	r := activeRecorder.Load()
+	if r == nil {
+		return
+	}
+
+	rec := CaughtRecord{Time: time.Now(), Err: err, Stack: string(debug.Stack())}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.records[r.next] = rec
+	r.next++
+	if r.next == len(r.records) {
+		r.next = 0
+		r.full = true
+	}
+}
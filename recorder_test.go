@@ -0,0 +1,69 @@
+package errorcat_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+func catchNumber(i int) {
+	_ = cat.Guard(func(ct cat.Context) error {
+		ct.Catch(fmt.Errorf("error %d", i))
+		return nil
+	})
+}
+
+// The recorder keeps the most recent catches in order.
+func TestRecorder(t *testing.T) {
+	assert.Nil(t, cat.RecentCatches())
+
+	cat.SetRecorder(3)
+	t.Cleanup(func() { cat.SetRecorder(0) })
+
+	catchNumber(1)
+	catchNumber(2)
+	records := cat.RecentCatches()
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, "error 1", records[0].Err.Error())
+		assert.Equal(t, "error 2", records[1].Err.Error())
+		assert.Contains(t, records[0].Stack, "catchNumber")
+		assert.False(t, records[0].Time.IsZero())
+	}
+
+	// The ring buffer wraps, discarding the oldest records.
+	for i := 3; i <= 7; i++ {
+		catchNumber(i)
+	}
+
+	var messages []string
+	for _, rec := range cat.RecentCatches() {
+		messages = append(messages, rec.Err.Error())
+	}
+	assert.Equal(t, []string{"error 5", "error 6", "error 7"}, messages)
+
+	cat.SetRecorder(0)
+	catchNumber(8)
+	assert.Nil(t, cat.RecentCatches())
+}
+
+// The recorder is safe under concurrent catches.
+func TestRecorderConcurrent(t *testing.T) {
+	cat.SetRecorder(10)
+	t.Cleanup(func() { cat.SetRecorder(0) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			catchNumber(i)
+			cat.RecentCatches()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, cat.RecentCatches(), 10)
+}
@@ -0,0 +1,50 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// Propagated by [ReentrancyGuard.Enter] when the guarded code is already running.
+var ErrReentrant = errors.New("re-entrant call")
+
+/*
+A ReentrancyGuard enforces that a section of code is not entered again while it's already
+running, from the same goroutine or another. This turns a "must not be called
+re-entrantly" invariant into a caught error rather than a subtle data race.
+
+	var processGuard = cat.NewReentrancyGuard()
+
+	func process() {
+		defer processGuard.Enter("process is not re-entrant")()
+		...
+	}
+*/
+type ReentrancyGuard struct {
+	entered atomic.Bool
+}
+
+// Creates a new [ReentrancyGuard].
+func NewReentrancyGuard() *ReentrancyGuard {
+	return &ReentrancyGuard{}
+}
+
+// Marks the guarded section as entered, returning a function to release it. If the
+// section is already entered, [ErrReentrant] is caught, annotated with `problem` in the
+// same way as [Catch]. Calling the release function more than once has no effect.
+func (g *ReentrancyGuard) Enter(problem ...any) (release func()) {
+	if !g.entered.CompareAndSwap(false, true) {
+		Catch(ErrReentrant, problem...)
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			g.entered.Store(false)
+		})
+	}
+}
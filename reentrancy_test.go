@@ -0,0 +1,64 @@
+package errorcat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// Sequential calls succeed, while re-entrant calls are caught.
+func TestReentrancyGuard(t *testing.T) {
+	guard := cat.NewReentrancyGuard()
+
+	var process func(depth int)
+	process = func(depth int) {
+		defer guard.Enter("process is not re-entrant")()
+		if depth > 0 {
+			process(depth - 1)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		err := cat.Guard(func(ct cat.Context) error {
+			process(0)
+			return nil
+		})
+		assert.NoError(t, err)
+	}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		process(1)
+		return nil
+	})
+	assert.Equal(t, "process is not re-entrant: re-entrant call", err.Error())
+	assert.ErrorIs(t, err, cat.ErrReentrant)
+
+	// The guard was released by the outer call while unwinding.
+	err = cat.Guard(func(ct cat.Context) error {
+		process(0)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+// Entering from another goroutine while the section is held is also caught.
+func TestReentrancyGuardConcurrent(t *testing.T) {
+	guard := cat.NewReentrancyGuard()
+
+	release := guard.Enter()
+	err := <-cat.Go(func(ct cat.Context) error {
+		defer guard.Enter()()
+		return nil
+	})
+	assert.ErrorIs(t, err, cat.ErrReentrant)
+
+	release()
+	release() // No effect.
+
+	err = <-cat.Go(func(ct cat.Context) error {
+		defer guard.Enter()()
+		return nil
+	})
+	assert.NoError(t, err)
+}
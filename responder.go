@@ -0,0 +1,104 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import "errors"
+
+// A service response computed from an error by a [Responder].
+type Response struct {
+	// Status code, e.g., an HTTP status.
+	Status int
+
+	// Response body produced by the renderer.
+	Body any
+}
+
+/*
+A Responder centralizes how caught errors are translated into service responses. Sentinel
+errors are mapped to status codes, and a renderer creates the response body.
+
+	var responder = cat.NewResponder(map[error]int{
+		ErrBadRequest: http.StatusBadRequest,
+		ErrNotFound:   http.StatusNotFound,
+	}, func(err error) any {
+		return map[string]string{"error": err.Error()}
+	})
+
+	func HandleRequest(w http.ResponseWriter, r *http.Request) {
+		err := cat.Guard(func(ct cat.Context) error {
+			...
+		}, responder.Annotator())
+
+		if resp, ok := cat.ResponseOf(err); ok {
+			writeJSON(w, resp.Status, resp.Body)
+		}
+	}
+*/
+type Responder struct {
+	// Status for errors that don't match any of the sentinels. NewResponder sets it to
+	// 500 (Internal Server Error).
+	DefaultStatus int
+
+	codes    map[error]int
+	renderer func(err error) any
+}
+
+// Creates a new [Responder]. `codeMap` maps sentinel errors to status codes; errors are
+// matched with errors.Is. Errors that don't match anything are given DefaultStatus, which
+// is 500 unless changed. If `renderer` is nil, the body is the error message.
+func NewResponder(codeMap map[error]int, renderer func(err error) any) *Responder {
+	if renderer == nil {
+		renderer = func(err error) any {
+			return err.Error()
+		}
+	}
+	return &Responder{DefaultStatus: 500, codes: codeMap, renderer: renderer}
+}
+
+// Computes the response for an error. If more than one sentinel matches, the highest
+// status code is used, so the result doesn't depend on map ordering.
+func (r *Responder) Respond(err error) Response {
+	status := 0
+	for sentinel, code := range r.codes {
+		if code > status && errors.Is(err, sentinel) {
+			status = code
+		}
+	}
+	if status == 0 {
+		status = r.DefaultStatus
+	}
+	return Response{Status: status, Body: r.renderer(err)}
+}
+
+// Returns an annotator that attaches the computed response to the error. Place it at
+// the end of the annotator chain so that the response reflects the final error. The
+// response can be read with [ResponseOf].
+func (r *Responder) Annotator() Annotator {
+	return func(err error) error {
+		return &responseError{err: err, response: r.Respond(err)}
+	}
+}
+
+// Attaches a response to an error in the chain.
+type responseError struct {
+	err      error
+	response Response
+}
+
+func (e *responseError) Error() string {
+	return e.err.Error()
+}
+
+func (e *responseError) Unwrap() error {
+	return e.err
+}
+
+// Returns the response attached by a [Responder] annotator, if any.
+func ResponseOf(err error) (Response, bool) {
+	var re *responseError
+	if errors.As(err, &re) {
+		return re.response, true
+	}
+	return Response{}, false
+}
@@ -0,0 +1,73 @@
+package errorcat_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+var errBadRequest = errors.New("bad request")
+var errNotFound = errors.New("not found")
+
+func newTestResponder() *cat.Responder {
+	return cat.NewResponder(map[error]int{
+		errBadRequest: http.StatusBadRequest,
+		errNotFound:   http.StatusNotFound,
+	}, func(err error) any {
+		return map[string]string{"error": err.Error()}
+	})
+}
+
+// Mapped errors are given their status code and a rendered body.
+func TestResponderMapped(t *testing.T) {
+	responder := newTestResponder()
+
+	err := cat.Guard(func(ct cat.Context) error {
+		ct.Catch(true, fmt.Errorf("%w: user cannot be empty", errBadRequest))
+		return nil
+	}, responder.Annotator())
+
+	resp, ok := cat.ResponseOf(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, resp.Status)
+	assert.Equal(t, map[string]string{"error": "bad request: user cannot be empty"}, resp.Body)
+
+	// The error is otherwise unchanged.
+	assert.Equal(t, "bad request: user cannot be empty", err.Error())
+	assert.ErrorIs(t, err, errBadRequest)
+}
+
+// Unmapped errors are internal errors.
+func TestResponderUnmapped(t *testing.T) {
+	responder := newTestResponder()
+
+	err := cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest, "database failed")
+		return nil
+	}, responder.Annotator())
+
+	resp, ok := cat.ResponseOf(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusInternalServerError, resp.Status)
+	assert.Equal(t, map[string]string{"error": "database failed: test-error"}, resp.Body)
+
+	// Errors that didn't pass through the annotator don't have a response.
+	_, ok = cat.ResponseOf(errTest)
+	assert.False(t, ok)
+
+	// The default status can be changed, e.g., for non-HTTP services.
+	responder.DefaultStatus = 13
+	assert.Equal(t, 13, responder.Respond(errTest).Status)
+}
+
+// Without a renderer, the body is the error message.
+func TestResponderDefaultRenderer(t *testing.T) {
+	responder := cat.NewResponder(map[error]int{errNotFound: http.StatusNotFound}, nil)
+
+	resp := responder.Respond(fmt.Errorf("user: %w", errNotFound))
+	assert.Equal(t, cat.Response{Status: http.StatusNotFound, Body: "user: not found"}, resp)
+}
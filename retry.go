@@ -0,0 +1,36 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import "time"
+
+// Calls `fn` in a guard up to `attempts` times, waiting `backoff` between attempts, until
+// it succeeds. Returns nil on success, or the error from the last attempt. Each attempt
+// has its own guard, so a failure in one doesn't affect the next. An `attempts` value
+// less than 1 is treated as 1.
+func Retry(attempts int, backoff time.Duration, fn GuardFunc) error {
+	return retry(attempts, backoff, nil, fn)
+}
+
+// Same as [Retry], but only retries errors for which `shouldRetry` returns true, e.g.,
+// network timeouts. Other errors are returned immediately. There's no delay between
+// attempts; `shouldRetry` can sleep if one is needed.
+func RetryIf(attempts int, shouldRetry func(err error) bool, fn GuardFunc) error {
+	return retry(attempts, 0, shouldRetry, fn)
+}
+
+// Implements [Retry] and [RetryIf].
+func retry(attempts int, backoff time.Duration, shouldRetry func(err error) bool, fn GuardFunc) error {
+	var err error
+	for i := 0; i < attempts || i == 0; i++ {
+		if i > 0 && backoff > 0 {
+			time.Sleep(backoff)
+		}
+		err = Guard(fn)
+		if err == nil || (shouldRetry != nil && !shouldRetry(err)) {
+			return err
+		}
+	}
+	return err
+}
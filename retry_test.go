@@ -0,0 +1,72 @@
+package errorcat_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// Retry stops at the first success.
+func TestRetry(t *testing.T) {
+	calls := 0
+	err := cat.Retry(5, time.Millisecond, func(ct cat.Context) error {
+		calls++
+		if calls == 1 {
+			panic("attempt 1 crashed")
+		}
+		ct.Catch(calls < 3, "not ready")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+// Retry returns the last error if every attempt fails.
+func TestRetryExhausted(t *testing.T) {
+	calls := 0
+	err := cat.Retry(3, 0, func(ct cat.Context) error {
+		calls++
+		ct.Catch(errTest, fmt.Sprint("attempt ", calls))
+		return nil
+	})
+	assert.EqualError(t, err, "attempt 3: test-error")
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	err = cat.Retry(0, 0, func(ct cat.Context) error {
+		calls++
+		return errTest
+	})
+	assert.Equal(t, errTest, err)
+	assert.Equal(t, 1, calls)
+}
+
+// RetryIf fails fast for errors that shouldn't be retried.
+func TestRetryIf(t *testing.T) {
+	isTimeout := func(err error) bool {
+		return errors.Is(err, context.DeadlineExceeded)
+	}
+
+	calls := 0
+	err := cat.RetryIf(5, isTimeout, func(ct cat.Context) error {
+		calls++
+		ct.Catch(calls < 3, context.DeadlineExceeded)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	err = cat.RetryIf(5, isTimeout, func(ct cat.Context) error {
+		calls++
+		ct.Catch(errTest, "permanent failure")
+		return nil
+	})
+	assert.EqualError(t, err, "permanent failure: test-error")
+	assert.Equal(t, 1, calls)
+}
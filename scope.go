@@ -0,0 +1,59 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+/*
+A Scope gives a component a guarded-method idiom with its own error policy. Components
+embed it and set the annotators that apply to all of their guards:
+
+	type UserService struct {
+		cat.Scope
+		db *sql.DB
+	}
+
+	func NewUserService(db *sql.DB) *UserService {
+		return &UserService{Scope: cat.Scope{Annotators: []any{"userservice"}}, db: db}
+	}
+
+	func (s *UserService) GetUser(id string) (user *User, rerr error) {
+		return user, s.Guard(func(ct cat.Context) error {
+			user = s.loadUser(ct, id)
+			return nil
+		})
+	}
+
+	func (s *UserService) loadUser(ct cat.Context, id string) *User {
+		...
+		s.Catch(ct, err, "query failed") // ct must come from s.Guard.
+	}
+
+Unlike [Context.Catch], [Scope.Catch] verifies that the context comes from the
+component's own guard.
+*/
+type Scope struct {
+	// Annotators applied by Guard, the same as the `annotate` parameters of [Guard].
+	Annotators []any
+}
+
+// Key for marking contexts created by a scope's Guard.
+type scopeKey struct {
+	scope *Scope
+}
+
+// Same as [Guard], but the scope's annotators are applied, and `ct` can be used with
+// [Scope.Catch].
+func (s *Scope) Guard(fn GuardFunc) error {
+	return guard(func(ct Context) error {
+		return fn(ct.(ExtendedContext).WithValue(scopeKey{s}, true))
+	}, s.Annotators)
+}
+
+// Same as [Context.Catch], but panics if `ct` doesn't come from the scope's [Scope.Guard].
+// Child contexts created with Sub count as coming from the guard.
+func (s *Scope) Catch(ct Context, condition any, problem ...any) {
+	if ext, ok := ct.(ExtendedContext); !ok || ext.Value(scopeKey{s}) == nil {
+		panic("[errorcat] Scope.Catch was called outside of the scope's Guard.")
+	}
+	ct.Catch(condition, problem...)
+}
@@ -0,0 +1,71 @@
+package errorcat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+type orderService struct {
+	cat.Scope
+}
+
+func (s *orderService) PlaceOrder(quantity int) error {
+	return s.Guard(func(ct cat.Context) error {
+		s.checkQuantity(ct, quantity)
+		return nil
+	})
+}
+
+func (s *orderService) checkQuantity(ct cat.Context, quantity int) {
+	s.Catch(ct, quantity <= 0, "quantity must be positive")
+}
+
+// A component's guard applies its annotators.
+func TestScope(t *testing.T) {
+	s := &orderService{Scope: cat.Scope{Annotators: []any{"orderservice"}}}
+
+	assert.NoError(t, s.PlaceOrder(1))
+	assert.EqualError(t, s.PlaceOrder(0), "orderservice: quantity must be positive")
+
+	// A zero scope has no annotators.
+	assert.EqualError(t, (&orderService{}).PlaceOrder(0), "quantity must be positive")
+}
+
+// Scope.Catch with a context from outside of the scope's own guard is detected.
+func TestScopeCatchOutsideGuard(t *testing.T) {
+	s := &orderService{}
+	other := &orderService{}
+	const message = "[errorcat] Scope.Catch was called outside of the scope's Guard."
+
+	assert.PanicsWithValue(t, message, func() {
+		s.checkQuantity(nil, 1)
+	})
+
+	// Another component's guard doesn't count. It recovers the panic like any other.
+	err := other.Guard(func(ct cat.Context) error {
+		s.checkQuantity(ct, 1)
+		return nil
+	})
+	assert.EqualError(t, err, message)
+
+	// Nor does a plain guard.
+	err = cat.Guard(func(ct cat.Context) error {
+		s.checkQuantity(ct, 1)
+		return nil
+	})
+	assert.EqualError(t, err, message)
+
+	// Child contexts of the scope's guard do.
+	err = s.Guard(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		sub, done := ext.Sub("check")
+		func() {
+			defer done()
+			s.checkQuantity(sub, 0)
+		}()
+		return nil
+	})
+	assert.EqualError(t, err, "check: quantity must be positive")
+}
@@ -0,0 +1,295 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+/*
+This package provides Errorcat helpers for semantic versions (https://semver.org), e.g.,
+for release tooling and plugin compatibility checks. Parsing is self-contained.
+
+	func loadPlugin(p *Plugin) (rerr error) {
+		defer errorcat.Recover(&rerr, "loading plugin "+p.Name)
+
+		v := semvercat.CatchSemver(p.Version, "bad plugin version")
+		semvercat.CatchSemverConstraint(v, ">=1.2.0, <2.0.0", "unsupported plugin")
+		...
+	}
+*/
+package semvercat
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mukunda.com/errorcat"
+)
+
+// Caught when a version or constraint is malformed.
+var ErrInvalid = errors.New("invalid version")
+
+// Caught when a version doesn't satisfy a constraint.
+var ErrUnsatisfied = errors.New("version constraint not satisfied")
+
+// A parsed semantic version.
+type Semver struct {
+	Major, Minor, Patch int
+
+	// Pre-release identifiers, e.g., "rc.1" in "1.0.0-rc.1". Empty for releases.
+	Pre string
+
+	// Build metadata, e.g., "build.5" in "1.0.0+build.5". It doesn't affect ordering.
+	Build string
+}
+
+// Formats the version, e.g., "1.0.0-rc.1+build.5".
+func (v Semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Returns -1, 0, or 1 if `v` orders before, the same as, or after `w`, according to
+// semver precedence. Build metadata is ignored.
+func (v Semver) Compare(w Semver) int {
+	if c := compareInt(v.Major, w.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, w.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, w.Patch); c != 0 {
+		return c
+	}
+	return comparePre(v.Pre, w.Pre)
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Compares pre-release strings. A release orders after any pre-release of the same
+// version.
+func comparePre(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareInt(an, bn); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			// Numeric identifiers order before alphanumeric ones.
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInt(len(as), len(bs))
+}
+
+// Parses a number without leading zeros.
+func parseNumber(s string) (int, bool) {
+	if s == "" || (len(s) > 1 && s[0] == '0') {
+		return 0, false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+// Validates dot-separated identifiers. Numeric identifiers in pre-releases can't have
+// leading zeros.
+func validIdentifiers(s string, pre bool) bool {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		numeric := true
+		for _, c := range id {
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				numeric = false
+			default:
+				return false
+			}
+		}
+		if pre && numeric && len(id) > 1 && id[0] == '0' {
+			return false
+		}
+	}
+	return true
+}
+
+// Parses a semantic version, e.g., "1.2.3" or "1.0.0-rc.1". A leading "v" is allowed, as in
+// Go module versions. Errors wrap [ErrInvalid] and say what's wrong.
+func Parse(s string) (Semver, error) {
+	var v Semver
+	rest := strings.TrimPrefix(s, "v")
+
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		rest, v.Build = rest[:i], rest[i+1:]
+		if !validIdentifiers(v.Build, false) {
+			return Semver{}, fmt.Errorf("%w %q: malformed build metadata", ErrInvalid, s)
+		}
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		rest, v.Pre = rest[:i], rest[i+1:]
+		if !validIdentifiers(v.Pre, true) {
+			return Semver{}, fmt.Errorf("%w %q: malformed pre-release", ErrInvalid, s)
+		}
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return Semver{}, fmt.Errorf("%w %q: expected MAJOR.MINOR.PATCH", ErrInvalid, s)
+	}
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, ok := parseNumber(part)
+		if !ok {
+			return Semver{}, fmt.Errorf("%w %q: %q is not a valid version number", ErrInvalid, s, part)
+		}
+		*numbers[i] = n
+	}
+	return v, nil
+}
+
+// Parses a semantic version with [Parse], catching [ErrInvalid] if it's malformed.
+// `problem` works the same as in [errorcat.Catch].
+func CatchSemver(s string, problem ...any) Semver {
+	v, err := Parse(s)
+	errorcat.Catch(err, problem...)
+	return v
+}
+
+// Returns whether `v` satisfies a single comparison, e.g., ">=1.2.0".
+func satisfies(v Semver, comparison string) (bool, error) {
+	ops := []string{">=", "<=", "!=", ">", "<", "=", "^", "~"}
+	op := "="
+	for _, o := range ops {
+		if strings.HasPrefix(comparison, o) {
+			op = o
+			comparison = comparison[len(o):]
+			break
+		}
+	}
+
+	w, err := Parse(strings.TrimSpace(comparison))
+	if err != nil {
+		return false, err
+	}
+
+	c := v.Compare(w)
+	switch op {
+	case ">=":
+		return c >= 0, nil
+	case "<=":
+		return c <= 0, nil
+	case "!=":
+		return c != 0, nil
+	case ">":
+		return c > 0, nil
+	case "<":
+		return c < 0, nil
+	case "^":
+		// Same major version, or same minor version for 0.x.
+		if w.Major == 0 {
+			return c >= 0 && v.Major == 0 && v.Minor == w.Minor, nil
+		}
+		return c >= 0 && v.Major == w.Major, nil
+	case "~":
+		return c >= 0 && v.Major == w.Major && v.Minor == w.Minor, nil
+	}
+	return c == 0, nil
+}
+
+// Returns whether `v` satisfies the constraint. See [CatchSemverConstraint] for the
+// syntax. Errors wrap [ErrInvalid] if the constraint is malformed.
+func Satisfies(v Semver, constraint string) (bool, error) {
+	comparisons := strings.Split(constraint, ",")
+	for _, comparison := range comparisons {
+		ok, err := satisfies(v, strings.TrimSpace(comparison))
+		if err != nil {
+			return false, fmt.Errorf("bad constraint %q: %w", constraint, err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+/*
+Catches [ErrUnsatisfied] if `v` doesn't satisfy the constraint, with a message stating the
+expectation, e.g., `version 1.1.0 does not satisfy ">=1.2.0"`. A malformed constraint is
+caught as [ErrInvalid]. `problem` works the same as in [errorcat.Catch].
+
+A constraint is a comma-separated list of comparisons, all of which must be satisfied,
+e.g., ">=1.2.0, <2.0.0". The operators are =, !=, >, >=, <, <=, ^ (compatible: same major
+version, or same minor version for 0.x), and ~ (same minor version). A version without an
+operator must match exactly.
+*/
+func CatchSemverConstraint(v Semver, constraint string, problem ...any) {
+	ok, err := Satisfies(v, constraint)
+	errorcat.Catch(err, problem...)
+	if !ok {
+		errorcat.Catch(fmt.Errorf("%w: version %s does not satisfy %q", ErrUnsatisfied, v, constraint),
+			problem...)
+	}
+}
+
+// Caught by [CatchVersionMismatch] and [CatchIncompatible] when versions are
+// incompatible.
+var ErrIncompatible = errors.New("incompatible version")
+
+// Catches [ErrIncompatible] if `have` doesn't satisfy the constraint, with a message like
+// "incompatible version: have 1.1.0, want >=1.2.0, <2.0.0". This is for failing fast at
+// module boundaries when a plugin or client is too old or too new. See
+// [CatchSemverConstraint] for the constraint syntax.
+func CatchVersionMismatch(have Semver, constraint string, problem ...any) {
+	ok, err := Satisfies(have, constraint)
+	errorcat.Catch(err, problem...)
+	if !ok {
+		errorcat.Catch(fmt.Errorf("%w: have %s, want %s", ErrIncompatible, have, constraint),
+			problem...)
+	}
+}
+
+// Catches [ErrIncompatible] if version `have` is not compatible with version `want`,
+// i.e., it's older or has a different major version (or minor version for 0.x). Both
+// versions are parsed with [CatchSemver].
+func CatchIncompatible(have, want string, problem ...any) {
+	CatchVersionMismatch(CatchSemver(have, problem...), "^"+CatchSemver(want, problem...).String(),
+		problem...)
+}
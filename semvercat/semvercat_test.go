@@ -0,0 +1,154 @@
+package semvercat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mukunda.com/errorcat"
+	"go.mukunda.com/errorcat/semvercat"
+)
+
+// Valid versions are parsed into their parts.
+func TestCatchSemverValid(t *testing.T) {
+	cases := map[string]semvercat.Semver{
+		"1.2.3":                  {Major: 1, Minor: 2, Patch: 3},
+		"v0.10.0":                {Minor: 10},
+		"1.0.0-rc.1":             {Major: 1, Pre: "rc.1"},
+		"1.0.0-alpha-1+build.05": {Major: 1, Pre: "alpha-1", Build: "build.05"},
+	}
+	for s, expected := range cases {
+		var v semvercat.Semver
+		err := errorcat.Guard(func(ct errorcat.Context) error {
+			v = semvercat.CatchSemver(s)
+			return nil
+		})
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, v)
+	}
+
+	v, _ := semvercat.Parse("v1.0.0-rc.1+build.5")
+	assert.Equal(t, "1.0.0-rc.1+build.5", v.String())
+}
+
+// Malformed versions are caught with a message saying what's wrong.
+func TestCatchSemverMalformed(t *testing.T) {
+	cases := map[string]string{
+		"1.2":         `bad version: invalid version "1.2": expected MAJOR.MINOR.PATCH`,
+		"1.2.3.4":     `bad version: invalid version "1.2.3.4": expected MAJOR.MINOR.PATCH`,
+		"1.02.3":      `bad version: invalid version "1.02.3": "02" is not a valid version number`,
+		"1.x.3":       `bad version: invalid version "1.x.3": "x" is not a valid version number`,
+		"1.2.3-":      `bad version: invalid version "1.2.3-": malformed pre-release`,
+		"1.2.3-rc.01": `bad version: invalid version "1.2.3-rc.01": malformed pre-release`,
+		"1.2.3+a..b":  `bad version: invalid version "1.2.3+a..b": malformed build metadata`,
+		"":            `bad version: invalid version "": expected MAJOR.MINOR.PATCH`,
+	}
+	for s, expected := range cases {
+		err := errorcat.Guard(func(ct errorcat.Context) error {
+			semvercat.CatchSemver(s, "bad version")
+			return nil
+		})
+		assert.ErrorIs(t, err, semvercat.ErrInvalid)
+		assert.EqualError(t, err, expected)
+	}
+}
+
+// Versions are ordered by semver precedence.
+func TestCompare(t *testing.T) {
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0",
+	}
+	for i := 1; i < len(ordered); i++ {
+		a, _ := semvercat.Parse(ordered[i-1])
+		b, _ := semvercat.Parse(ordered[i])
+		assert.Equal(t, -1, a.Compare(b), "%s < %s", a, b)
+		assert.Equal(t, 1, b.Compare(a), "%s > %s", b, a)
+	}
+
+	a, _ := semvercat.Parse("1.0.0+a")
+	b, _ := semvercat.Parse("1.0.0+b")
+	assert.Equal(t, 0, a.Compare(b))
+}
+
+// Constraint violations are caught with the expectation in the message.
+func TestCatchSemverConstraint(t *testing.T) {
+	satisfied := [][2]string{
+		{"1.2.0", ">=1.2.0"},
+		{"1.9.9", ">=1.2.0, <2.0.0"},
+		{"1.5.0", "^1.2.0"},
+		{"0.2.5", "^0.2.0"},
+		{"1.2.9", "~1.2.0"},
+		{"1.0.0", "1.0.0"},
+		{"1.0.1", "!=1.0.0"},
+		{"2.0.0-rc.1", "<2.0.0"},
+		{"1.2.0-rc.1", "> 1.1.0"},
+		{"1.2.0+build", "=1.2.0"},
+	}
+	for _, c := range satisfied {
+		s, constraint := c[0], c[1]
+		err := errorcat.Guard(func(ct errorcat.Context) error {
+			semvercat.CatchSemverConstraint(semvercat.CatchSemver(s), constraint)
+			return nil
+		})
+		assert.NoError(t, err, "%s %s", s, constraint)
+	}
+
+	unsatisfied := [][2]string{
+		{"1.1.0", ">=1.2.0"},
+		{"2.0.0", ">=1.2.0, <2.0.0"},
+		{"2.0.0", "^1.2.0"},
+		{"0.3.0", "^0.2.0"},
+		{"1.3.0", "~1.2.0"},
+		{"1.0.1", "1.0.0"},
+	}
+	for _, c := range unsatisfied {
+		s, constraint := c[0], c[1]
+		err := errorcat.Guard(func(ct errorcat.Context) error {
+			semvercat.CatchSemverConstraint(semvercat.CatchSemver(s), constraint, "unsupported plugin")
+			return nil
+		})
+		assert.ErrorIs(t, err, semvercat.ErrUnsatisfied)
+		assert.EqualError(t, err, "unsupported plugin: version constraint not satisfied: version "+
+			s+" does not satisfy \""+constraint+"\"")
+	}
+
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		semvercat.CatchSemverConstraint(semvercat.Semver{Major: 1}, ">=1.x")
+		return nil
+	})
+	assert.ErrorIs(t, err, semvercat.ErrInvalid)
+	assert.EqualError(t, err, `bad constraint ">=1.x": invalid version "1.x": expected MAJOR.MINOR.PATCH`)
+}
+
+// Incompatible versions are caught with the expectation in the message.
+func TestCatchVersionMismatch(t *testing.T) {
+	check := func(have, want string) error {
+		return errorcat.Guard(func(ct errorcat.Context) error {
+			semvercat.CatchIncompatible(have, want, "plugin rejected")
+			return nil
+		})
+	}
+
+	assert.NoError(t, check("1.2.0", "1.2.0"))
+	assert.NoError(t, check("1.5.3", "1.2.0"))
+	assert.NoError(t, check("0.2.1", "0.2.0"))
+
+	err := check("1.1.0", "1.2.0")
+	assert.ErrorIs(t, err, semvercat.ErrIncompatible)
+	assert.EqualError(t, err, "plugin rejected: incompatible version: have 1.1.0, want ^1.2.0")
+
+	err = check("2.0.0", "1.2.0")
+	assert.EqualError(t, err, "plugin rejected: incompatible version: have 2.0.0, want ^1.2.0")
+
+	err = check("0.3.0", "0.2.0")
+	assert.ErrorIs(t, err, semvercat.ErrIncompatible)
+
+	err = check("one", "1.2.0")
+	assert.ErrorIs(t, err, semvercat.ErrInvalid)
+
+	err = errorcat.Guard(func(ct errorcat.Context) error {
+		semvercat.CatchVersionMismatch(semvercat.Semver{Major: 3}, ">=1.0.0, <3.0.0")
+		return nil
+	})
+	assert.EqualError(t, err, "incompatible version: have 3.0.0, want >=1.0.0, <3.0.0")
+}
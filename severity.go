@@ -0,0 +1,67 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import "errors"
+
+// The severity of a caught error. Errors caught without a severity are [SeverityError].
+type Severity int
+
+const (
+	// A minor error. It's captured like any other error; the severity is informational.
+	SeverityWarning Severity = iota - 1
+
+	// The default severity. The error is captured by the nearest guard like normal.
+	SeverityError
+
+	// An unrecoverable condition, e.g., a broken invariant that should never happen. Fatal
+	// errors are not captured by guards. They are annotated and then propagated as a real
+	// panic, crashing the process unless something other than Errorcat recovers it.
+	SeverityFatal
+)
+
+// Returns a readable name for the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityFatal:
+		return "fatal"
+	}
+	return "unknown"
+}
+
+// Attaches a severity to an error in the chain.
+type severityError struct {
+	err      error
+	severity Severity
+}
+
+func (e *severityError) Error() string {
+	return e.err.Error()
+}
+
+func (e *severityError) Unwrap() error {
+	return e.err
+}
+
+// Same as [Catch], but the propagated error is tagged with a severity that can be read
+// with [SeverityOf]. Errors with [SeverityFatal] will escape all guards.
+func CatchSev(severity Severity, condition any, problem ...any) {
+	if err := caught(condition, problem); err != nil {
+		throw(&severityError{err: err, severity: severity})
+	}
+}
+
+// Returns the severity that the error was caught with, or [SeverityError] if it wasn't
+// tagged with a severity.
+func SeverityOf(err error) Severity {
+	var se *severityError
+	if errors.As(err, &se) {
+		return se.severity
+	}
+	return SeverityError
+}
@@ -0,0 +1,66 @@
+package errorcat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// Errors caught with a severity can have the severity read back after recovery.
+func TestCatchSev(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.CatchSev(cat.SeverityWarning, errTest, "problem")
+		return nil
+	}, "outer")
+
+	assert.Equal(t, "outer: problem: test-error", err.Error())
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, cat.SeverityWarning, cat.SeverityOf(err))
+
+	// Untagged errors are normal errors.
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.Catch(errTest)
+		return nil
+	})
+	assert.Equal(t, cat.SeverityError, cat.SeverityOf(err))
+
+	// Nothing happens if the condition doesn't trigger.
+	assert.NotPanics(t, func() {
+		cat.CatchSev(cat.SeverityFatal, false, "problem")
+		cat.CatchSev(cat.SeverityFatal, nil, "problem")
+	})
+}
+
+// Fatal errors escape all guards as a real panic, after the annotators are applied.
+func TestCatchSevFatal(t *testing.T) {
+	var recovered any
+	annotated := false
+
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+
+		_ = cat.Guard(func(ct cat.Context) error {
+			return cat.Guard(func(ct cat.Context) error {
+				cat.CatchSev(cat.SeverityFatal, true, "invariant broken")
+				return nil
+			}, "inner", func(err error) error {
+				annotated = true
+				// Handling the error doesn't stop it either.
+				return nil
+			})
+		}, "outer")
+
+		assert.Fail(t, "this should not be reached")
+	}()
+
+	assert.True(t, annotated)
+	if assert.Implements(t, (*error)(nil), recovered) {
+		err := recovered.(error)
+		// The inner annotations were discarded when the handler returned nil.
+		assert.Equal(t, "outer: invariant broken", err.Error())
+		assert.Equal(t, cat.SeverityFatal, cat.SeverityOf(err))
+	}
+}
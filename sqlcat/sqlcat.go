@@ -0,0 +1,40 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+// This package provides Errorcat helpers for database/sql code.
+package sqlcat
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go.mukunda.com/errorcat"
+)
+
+// Errors caught by [CatchNotFound] for missing rows are tagged with this error, so that
+// handlers can check for it with errors.Is and respond with "not found" rather than an
+// internal error.
+var ErrNotFound = errors.New("not found")
+
+// Catches a database error. If the error is sql.ErrNoRows, it is tagged with [ErrNotFound]
+// before being caught. Other errors are caught normally, and nil passes through. `problem`
+// works the same as in [errorcat.Catch].
+//
+//	row := db.QueryRow("SELECT name FROM users WHERE id = ?", id)
+//	sqlcat.CatchNotFound(row.Scan(&name), "user lookup failed")
+func CatchNotFound(err error, problem ...any) {
+	if errors.Is(err, sql.ErrNoRows) {
+		err = fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	errorcat.Catch(err, problem...)
+}
+
+// Returns nil if the error is sql.ErrNoRows, otherwise returns the error unchanged. This
+// is for queries where a missing row is not an error.
+func IgnoreNoRows(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+package sqlcat_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mukunda.com/errorcat"
+	"go.mukunda.com/errorcat/sqlcat"
+)
+
+var errTest = errors.New("test-error")
+
+// Missing rows are caught as "not found" errors.
+func TestCatchNotFound(t *testing.T) {
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		sqlcat.CatchNotFound(fmt.Errorf("scan: %w", sql.ErrNoRows), "user lookup failed")
+		return nil
+	})
+
+	assert.Equal(t, "user lookup failed: not found: scan: sql: no rows in result set", err.Error())
+	assert.ErrorIs(t, err, sqlcat.ErrNotFound)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+// Other errors are caught normally.
+func TestCatchNotFoundOtherError(t *testing.T) {
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		sqlcat.CatchNotFound(errTest, "user lookup failed")
+		return nil
+	})
+
+	assert.Equal(t, "user lookup failed: test-error", err.Error())
+	assert.ErrorIs(t, err, errTest)
+	assert.NotErrorIs(t, err, sqlcat.ErrNotFound)
+}
+
+// Nil errors pass through.
+func TestCatchNotFoundNil(t *testing.T) {
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		sqlcat.CatchNotFound(nil, "user lookup failed")
+		return nil
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestIgnoreNoRows(t *testing.T) {
+	assert.NoError(t, sqlcat.IgnoreNoRows(sql.ErrNoRows))
+	assert.NoError(t, sqlcat.IgnoreNoRows(fmt.Errorf("scan: %w", sql.ErrNoRows)))
+	assert.NoError(t, sqlcat.IgnoreNoRows(nil))
+	assert.Equal(t, errTest, sqlcat.IgnoreNoRows(errTest))
+}
@@ -0,0 +1,142 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+var captureStack atomic.Bool
+
+// Enables stack capture, which is off by default since it has a cost on the error path.
+// When enabled, Catch records the stack at the point where it propagates an error, and
+// [Go] records the stack that launched the goroutine. Read them with [StackOf] or
+// [CatError.Stack].
+//
+// The stack is carried by a wrapper in the error chain, so it survives annotation. Note
+// that this means errors caught without a problem are no longer returned as-is; use
+// errors.Is to compare them.
+func SetCaptureStack(enabled bool) {
+	captureStack.Store(enabled)
+}
+
+// Returns the program counters of the current stack, starting at the caller of the
+// function that calls this.
+func callers() []uintptr {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
+// Attaches the stack of the Catch site to an error in the chain.
+type stackError struct {
+	err error
+	pcs []uintptr
+}
+
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+// Attaches the stack that launched a goroutine to an error from that goroutine.
+type launchError struct {
+	err error
+	pcs []uintptr
+}
+
+func (e *launchError) Error() string {
+	return e.err.Error()
+}
+
+func (e *launchError) Unwrap() error {
+	return e.err
+}
+
+// Returns the program counters captured at the Catch site, or nil if stack capture was
+// disabled. See [SetCaptureStack]. Use runtime.CallersFrames to resolve them.
+func (e CatError) StackTrace() []uintptr {
+	var se *stackError
+	if errors.As(e.err, &se) {
+		return se.pcs
+	}
+	return nil
+}
+
+// Returns the formatted stack of the Catch site, or "" if stack capture was disabled. This
+// is the same as [StackOf] for the caught error, which can be used after the error is
+// recovered and the CatError wrapper is removed.
+func (e CatError) Stack() string {
+	return StackOf(e.err)
+}
+
+// Attaches the stack that launched a goroutine to an error from it. `launch` is nil if
+// stack capture was disabled.
+func withLaunch(err error, launch []uintptr) error {
+	if err == nil || launch == nil {
+		return err
+	}
+	return &launchError{err: err, pcs: launch}
+}
+
+// Writes the frames of a stack, omitting frames of this package and the runtime.
+func formatStack(sb *strings.Builder, pcs []uintptr) {
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "go.mukunda.com/errorcat.") &&
+			!strings.HasPrefix(frame.Function, "runtime.") {
+			fmt.Fprintf(sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+}
+
+/*
+Returns the stack captured where the error was originally caught, or "" if there is none.
+See [SetCaptureStack]. If the error crossed goroutines launched with [Go], the launch
+stacks follow, innermost first, each under a "launched from:" line:
+
+	main.saveUser
+		/src/app/user.go:42
+	launched from:
+	main.handleRequest
+		/src/app/server.go:17
+
+Frames of Errorcat and the runtime are omitted.
+*/
+func StackOf(err error) string {
+	var site []uintptr
+	var launches [][]uintptr
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch e := err.(type) {
+		case *stackError:
+			// The innermost stack is the original Catch site.
+			site = e.pcs
+		case *launchError:
+			launches = append(launches, e.pcs)
+		}
+	}
+	if site == nil && launches == nil {
+		return ""
+	}
+
+	// The wrappers were found outermost first.
+	var sb strings.Builder
+	formatStack(&sb, site)
+	for i := len(launches) - 1; i >= 0; i-- {
+		sb.WriteString("launched from:\n")
+		formatStack(&sb, launches[i])
+	}
+	return sb.String()
+}
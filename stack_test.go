@@ -0,0 +1,72 @@
+package errorcat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+func failWorker(ct cat.Context) {
+	ct.Catch(errTest, "worker failed")
+}
+
+func launchWorker() error {
+	return <-cat.Go(func(ct cat.Context) error {
+		failWorker(ct)
+		return nil
+	}, "background job")
+}
+
+// Errors from goroutines carry both the failure site and the launch site.
+func TestStackAcrossGo(t *testing.T) {
+	err := launchWorker()
+	assert.Empty(t, cat.StackOf(err))
+
+	cat.SetCaptureStack(true)
+	t.Cleanup(func() { cat.SetCaptureStack(false) })
+
+	err = launchWorker()
+	assert.EqualError(t, err, "background job: worker failed: test-error")
+	assert.ErrorIs(t, err, errTest)
+
+	stack := cat.StackOf(err)
+	assert.Regexp(t, `(?s)^go.mukunda.com/errorcat_test.failWorker\n\t.*stack_test.go:\d+\n`+
+		`.*launched from:\n`+
+		`go.mukunda.com/errorcat_test.launchWorker\n\t.*stack_test.go:\d+\n`+
+		`go.mukunda.com/errorcat_test.TestStackAcrossGo\n`, stack)
+	assert.NotContains(t, stack, "go.mukunda.com/errorcat.")
+}
+
+func catchWithStack() {
+	cat.Catch(errTest, "deep failure")
+}
+
+// The stack points at the Catch site and survives recovery and annotation.
+func TestCatErrorStack(t *testing.T) {
+	catchPanic := func() (ce cat.CatError) {
+		defer func() {
+			ce = recover().(cat.CatError)
+		}()
+		catchWithStack()
+		return
+	}
+
+	ce := catchPanic()
+	assert.Nil(t, ce.StackTrace())
+	assert.Empty(t, ce.Stack())
+
+	cat.SetCaptureStack(true)
+	t.Cleanup(func() { cat.SetCaptureStack(false) })
+
+	ce = catchPanic()
+	assert.NotEmpty(t, ce.StackTrace())
+	assert.Regexp(t, `^go.mukunda.com/errorcat_test.catchWithStack\n`, ce.Stack())
+
+	err := cat.Guard(func(ct cat.Context) error {
+		catchWithStack()
+		return nil
+	}, "outer")
+	assert.EqualError(t, err, "outer: deep failure: test-error")
+	assert.Regexp(t, `^go.mukunda.com/errorcat_test.catchWithStack\n`, cat.StackOf(err))
+}
@@ -0,0 +1,38 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var (
+	catchTotal atomic.Uint64
+	statsStart = time.Now()
+)
+
+// Called whenever an error is propagated by Catch or one of its variants. This is on the
+// error path, so keep it cheap.
+func onCatch(err error) {
+	catchTotal.Add(1)
+	record(err)
+	runSentinelHooks(err)
+}
+
+// Returns the total number of errors propagated by Catch (and its variants) since the
+// program started, and the average number per second over that whole time. A high rate
+// can point to error paths that are hot enough to be worth returning errors normally
+// instead, as panicking is more expensive.
+//
+// The rate is a lifetime average, so it reacts slowly to recent bursts in a long-running
+// program. For a current rate, sample `total` periodically and take the difference.
+func CatchStats() (total uint64, lifetimeRate float64) {
+	total = catchTotal.Load()
+	elapsed := time.Since(statsStart).Seconds()
+	if elapsed > 0 {
+		lifetimeRate = float64(total) / elapsed
+	}
+	return total, lifetimeRate
+}
@@ -0,0 +1,26 @@
+package errorcat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// The catch counter increases for each triggered catch.
+func TestCatchStats(t *testing.T) {
+	before, _ := cat.CatchStats()
+
+	for i := 0; i < 3; i++ {
+		_ = cat.Guard(func(ct cat.Context) error {
+			ct.Catch(false, "not triggered")
+			ct.Catch(nil, "not triggered")
+			ct.Catch(errTest, "triggered")
+			return nil
+		})
+	}
+
+	after, rate := cat.CatchStats()
+	assert.Equal(t, before+3, after)
+	assert.Greater(t, rate, 0.0)
+}
@@ -0,0 +1,49 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	gocontext "context"
+	"fmt"
+	"time"
+)
+
+// Callback for [GuardTimeout]. `ctx` is canceled when the time budget runs out.
+type TimeoutFunc = func(ctx gocontext.Context, ct Context) error
+
+/*
+Same as [Guard], but `fn` has a time budget. It runs in its own guarded goroutine, and if
+it doesn't finish within `d`, an error wrapping context.DeadlineExceeded is returned. The
+annotations are applied to either result, in the calling goroutine:
+
+	err := cat.GuardTimeout(5*time.Second, func(ctx context.Context, ct cat.Context) error {
+		resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+		ct.Catch(err, "request failed")
+		...
+	}, "fetch failed")
+
+`ctx` is canceled on timeout so that `fn` can stop early. GuardTimeout doesn't wait for it,
+so if `fn` ignores cancellation, it may still be running after the timeout is returned.
+*/
+func GuardTimeout(d time.Duration, fn TimeoutFunc, annotate ...any) error {
+	return guard(func(ct Context) error {
+		ctx, cancel := gocontext.WithTimeout(gocontext.Background(), d)
+		defer cancel()
+
+		// Buffered so that a late result doesn't block the goroutine forever.
+		done := make(chan error, 1)
+		go func() {
+			done <- Guard(func(ct Context) error {
+				return fn(ctx, ct)
+			})
+		}()
+
+		select {
+		case err := <-done:
+			return err
+		case <-ctx.Done():
+			return fmt.Errorf("%w: timed out after %v", gocontext.DeadlineExceeded, d)
+		}
+	}, annotate)
+}
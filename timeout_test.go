@@ -0,0 +1,44 @@
+package errorcat_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// Functions that finish in time return their own result.
+func TestGuardTimeoutFast(t *testing.T) {
+	err := cat.GuardTimeout(time.Second, func(ctx context.Context, ct cat.Context) error {
+		return nil
+	}, "fetch failed")
+	assert.NoError(t, err)
+
+	err = cat.GuardTimeout(time.Second, func(ctx context.Context, ct cat.Context) error {
+		ct.Catch(errTest, "request failed")
+		return nil
+	}, "fetch failed")
+	assert.EqualError(t, err, "fetch failed: request failed: test-error")
+	assert.NotErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// On timeout, the context is canceled and an annotated deadline error is returned.
+func TestGuardTimeoutExpired(t *testing.T) {
+	canceled := make(chan struct{})
+	err := cat.GuardTimeout(10*time.Millisecond, func(ctx context.Context, ct cat.Context) error {
+		<-ctx.Done()
+		close(canceled)
+		return ctx.Err()
+	}, "fetch failed")
+
+	assert.EqualError(t, err, "fetch failed: context deadline exceeded: timed out after 10ms")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("context was not canceled")
+	}
+}
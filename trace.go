@@ -0,0 +1,62 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+var guardTrace atomic.Bool
+
+/*
+Enables guard tracing, which is off by default. When enabled, each [Guard] that returns an
+error records its site (the function that called Guard) on the error. When an error passes
+through nested guards, the sites accumulate, showing the error's path. Read them with
+[GuardTrace].
+
+Guards made by deferring [Recover] directly are not traced, since the deferring function
+can't be identified while a panic is unwinding.
+*/
+func SetGuardTrace(enabled bool) {
+	guardTrace.Store(enabled)
+}
+
+// Carries the list of guard sites that an error passed through.
+type traceError struct {
+	err   error
+	sites []string
+}
+
+func (e *traceError) Error() string {
+	return e.err.Error()
+}
+
+func (e *traceError) Unwrap() error {
+	return e.err
+}
+
+// Appends a guard site to the trace of an error.
+func traceGuard(rerr *error, site string) {
+	if *rerr == nil {
+		return
+	}
+
+	var sites []string
+	var te *traceError
+	if errors.As(*rerr, &te) {
+		sites = append(sites, te.sites...)
+	}
+	*rerr = &traceError{err: *rerr, sites: append(sites, site)}
+}
+
+// Returns the guard sites that the error passed through, innermost first, or nil if the
+// error wasn't traced. See [SetGuardTrace].
+func GuardTrace(err error) []string {
+	var te *traceError
+	if errors.As(err, &te) {
+		return te.sites
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package errorcat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+func traceLevel3() error {
+	return cat.Guard(func(ct cat.Context) error {
+		ct.Catch(errTest, "level 3 failed")
+		return nil
+	})
+}
+
+func traceLevel2() error {
+	return cat.Guard(func(ct cat.Context) error {
+		ct.Catch(traceLevel3())
+		return nil
+	}, "level 2")
+}
+
+func traceLevel1() error {
+	return cat.Guard(func(ct cat.Context) error {
+		return traceLevel2()
+	}, "level 1")
+}
+
+// Nested guards accumulate a trace of the error's path.
+func TestGuardTrace(t *testing.T) {
+	err := traceLevel1()
+	assert.Nil(t, cat.GuardTrace(err))
+
+	cat.SetGuardTrace(true)
+	t.Cleanup(func() { cat.SetGuardTrace(false) })
+
+	err = traceLevel1()
+	assert.EqualError(t, err, "level 1: level 2: level 3 failed: test-error")
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, []string{
+		"errorcat_test.traceLevel3",
+		"errorcat_test.traceLevel2",
+		"errorcat_test.traceLevel1",
+	}, cat.GuardTrace(err))
+
+	// Successful guards don't record anything.
+	assert.NoError(t, cat.Guard(func(ct cat.Context) error { return nil }))
+}
+
+// Bound guards are traced at the method that uses them.
+func TestGuardTraceMethods(t *testing.T) {
+	cat.SetGuardTrace(true)
+	t.Cleanup(func() { cat.SetGuardTrace(false) })
+
+	s := &testService{run: cat.GuardMethods("userservice")}
+	err := s.Delete("1")
+	assert.Equal(t, []string{"errorcat_test.(*testService).Delete"}, cat.GuardTrace(err))
+}
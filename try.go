@@ -0,0 +1,49 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+/*
+Returns `val`, or catches `err` if it's not nil. This is the same as [Catch] on the error
+side, but it avoids the two-line pattern for functions that return a value and an error:
+
+	f := cat.Try(os.Open("config.json"))
+
+Go only allows a multi-value call to fill all of the arguments, so `problem` can't be
+given together with a nested call. To annotate, use [Catch] or annotate in the guard.
+*/
+func Try[T any](val T, err error, problem ...any) T {
+	Catch(err, problem...)
+	return val
+}
+
+// Same as [Try], but for functions that return two values and an error:
+//
+//	host, port := cat.Try2(splitHostPort(addr))
+func Try2[A, B any](a A, b B, err error, problem ...any) (A, B) {
+	Catch(err, problem...)
+	return a, b
+}
+
+// Same as [Try], but for functions that return three values and an error.
+func Try3[A, B, C any](a A, b B, c C, err error, problem ...any) (A, B, C) {
+	Catch(err, problem...)
+	return a, b, c
+}
+
+/*
+Returns `val`, or propagates `err` if it's not nil. This is the errorcat version of the
+template.Must idiom, for calls that should never fail, e.g., parsing a constant:
+
+	re := cat.Must(regexp.Compile(pattern))
+
+Unlike [Try], Must doesn't take a problem, and the error is propagated exactly as it is,
+without a default problem from [SetDefaultProblem]. Unlike template.Must, the failure
+is a Catch, so an enclosing guard still captures it rather than crashing the process.
+*/
+func Must[T any](val T, err error) T {
+	if !isNil(err) {
+		throw(err)
+	}
+	return val
+}
@@ -0,0 +1,113 @@
+package errorcat_test
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// Try returns the value when there's no error.
+func TestTry(t *testing.T) {
+	err := cat.Guard(func(ct cat.Context) error {
+		assert.Equal(t, 42, cat.Try(strconv.Atoi("42")))
+		assert.Equal(t, "value", cat.Try("value", nil, "not triggered"))
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+// Try errors propagate like Catch.
+func TestTryError(t *testing.T) {
+	var reached bool
+	err := cat.Guard(func(ct cat.Context) error {
+		cat.Try(strconv.Atoi("forty-two"))
+		reached = true
+		return nil
+	})
+	assert.False(t, reached)
+	assert.EqualError(t, err, `strconv.Atoi: parsing "forty-two": invalid syntax`)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	err = cat.Guard(func(ct cat.Context) error {
+		cat.Try(0, errTest, "parsing failed")
+		return nil
+	})
+	assert.EqualError(t, err, "parsing failed: test-error")
+	assert.ErrorIs(t, err, errTest)
+
+	// The panic is a plain CatError.
+	defer func() {
+		var ce cat.CatError
+		assert.True(t, errors.As(recover().(error), &ce))
+	}()
+	cat.Try(0, errTest)
+}
+
+func threeValues(fail bool) (int, string, bool, error) {
+	if fail {
+		return 0, "", false, errTest
+	}
+	return 1, "two", true, nil
+}
+
+// Try2 and Try3 return the values before the error, and compose with scopes.
+func TestTryMultiple(t *testing.T) {
+	s := &cat.Scope{Annotators: []any{"scope"}}
+
+	err := s.Guard(func(ct cat.Context) error {
+		a, b, c := cat.Try3(threeValues(false))
+		assert.Equal(t, 1, a)
+		assert.Equal(t, "two", b)
+		assert.True(t, c)
+
+		key, value := cat.Try2(splitPair("a=b"))
+		assert.Equal(t, "a", key)
+		assert.Equal(t, "b", value)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = s.Guard(func(ct cat.Context) error {
+		cat.Try3(threeValues(true))
+		return nil
+	})
+	assert.EqualError(t, err, "scope: test-error")
+
+	err = s.Guard(func(ct cat.Context) error {
+		cat.Try2(1, 2, errTest, "pair failed")
+		return nil
+	})
+	assert.EqualError(t, err, "scope: pair failed: test-error")
+}
+
+// Must failures are recoverable, and the error is the source error.
+func TestMust(t *testing.T) {
+	var errSource = errors.New("source-error")
+	cat.SetDefaultProblem(errSource, "ignored by Must")
+	t.Cleanup(func() { cat.SetDefaultProblem(errSource, "") })
+
+	err := cat.Guard(func(ct cat.Context) error {
+		assert.Equal(t, 42, cat.Must(strconv.Atoi("42")))
+		cat.Must(0, errSource)
+		return nil
+	})
+	assert.Equal(t, errSource, err)
+}
+
+// A typed nil error is not a failure, the same as with Try and Catch.
+func TestMustTypedNil(t *testing.T) {
+	open := func() (int, error) {
+		var pathErr *os.PathError
+		return 42, pathErr
+	}
+
+	err := cat.Guard(func(ct cat.Context) error {
+		assert.Equal(t, 42, cat.Must(open()))
+		return nil
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,26 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+// This package provides an Errorcat helper for parsing UUIDs. It lives in its own module
+// so that the core package doesn't depend on a UUID library. For other formats, see
+// [errorcat.CatchFormat].
+package uuidcat
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.mukunda.com/errorcat"
+)
+
+// Parses a UUID, catching an error if it's not valid. `problem` works the same as in
+// [errorcat.Catch].
+//
+//	userID := uuidcat.CatchUUID(r.PathValue("id"), "invalid user ID")
+func CatchUUID(s string, problem ...any) uuid.UUID {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		errorcat.Catch(fmt.Errorf("invalid UUID %q: %w", s, err), problem...)
+	}
+	return id
+}
@@ -0,0 +1,29 @@
+package uuidcat_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.mukunda.com/errorcat"
+	"go.mukunda.com/errorcat/uuidcat"
+)
+
+// Valid UUIDs are parsed.
+func TestCatchUUID(t *testing.T) {
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		id := uuidcat.CatchUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+		assert.Equal(t, uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"), id)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+// Invalid UUIDs are caught.
+func TestCatchUUIDInvalid(t *testing.T) {
+	err := errorcat.Guard(func(ct errorcat.Context) error {
+		uuidcat.CatchUUID("not-a-uuid", "invalid user ID")
+		return nil
+	})
+	assert.EqualError(t, err, `invalid user ID: invalid UUID "not-a-uuid": invalid UUID length: 10`)
+}
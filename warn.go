@@ -0,0 +1,47 @@
+// errorcat - error catching utilities
+// (C) 2025 Mukunda Johnson (mukunda.com)
+
+package errorcat
+
+import "sync/atomic"
+
+var warnHandler atomic.Pointer[func(err error)]
+
+// Sets the handler for warnings from [Warn] and [ExtendedContext.Warn], e.g., to log them. The
+// default is to do nothing. Passing nil restores the default.
+func SetWarnHandler(fn func(err error)) {
+	if fn == nil {
+		warnHandler.Store(nil)
+		return
+	}
+	warnHandler.Store(&fn)
+}
+
+// Passes a warning to the handler set with [SetWarnHandler], if any.
+func handleWarning(err error) {
+	if fn := warnHandler.Load(); fn != nil {
+		(*fn)(err)
+	}
+}
+
+/*
+Same as [Catch], but a triggered condition is a warning that doesn't interrupt execution.
+The warning is formed the same way as the error from Catch and passed to the handler set
+with [SetWarnHandler]. Returns true if the condition triggered, so the caller can branch:
+
+	if cat.Warn(os.Remove(tmpFile), "couldn't remove temporary file") {
+		leftovers++
+	}
+
+This is for best-effort work, e.g., cleanup, where failures should be noted but not
+abort. It can be used outside of a guard. Use [ExtendedContext.Warn] to also collect the warnings
+for [GuardWarn].
+*/
+func Warn(condition any, problem ...any) bool {
+	err := formError(condition, problem)
+	if err == nil {
+		return false
+	}
+	handleWarning(err)
+	return true
+}
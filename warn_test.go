@@ -0,0 +1,69 @@
+package errorcat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cat "go.mukunda.com/errorcat"
+)
+
+// Warn passes the formatted warning to the handler without panicking.
+func TestWarn(t *testing.T) {
+	var warnings []error
+	cat.SetWarnHandler(func(err error) { warnings = append(warnings, err) })
+	t.Cleanup(func() { cat.SetWarnHandler(nil) })
+
+	assert.NotPanics(t, func() {
+		assert.False(t, cat.Warn(nil, "not a warning"))
+		assert.False(t, cat.Warn(false, "not a warning"))
+		assert.True(t, cat.Warn(errTest, "couldn't remove file"))
+		assert.True(t, cat.Warn(true, "cache is stale"))
+	})
+	if assert.Len(t, warnings, 2) {
+		assert.EqualError(t, warnings[0], "couldn't remove file: test-error")
+		assert.ErrorIs(t, warnings[0], errTest)
+		assert.EqualError(t, warnings[1], "cache is stale")
+	}
+
+	// Context warnings are passed to the handler too.
+	err, recorded := cat.GuardWarn(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		ext.Warn(errTest2)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []error{errTest2}, recorded)
+	assert.Equal(t, errTest2, warnings[2])
+
+	// Without a handler, warnings are dropped.
+	cat.SetWarnHandler(nil)
+	assert.True(t, cat.Warn(errTest))
+	assert.Len(t, warnings, 3)
+}
+
+// Strict mode only applies to Catch, so warnings never panic.
+func TestWarnRequireProblem(t *testing.T) {
+	cat.SetRequireProblem(true)
+	t.Cleanup(func() { cat.SetRequireProblem(false) })
+
+	var warning error
+	cat.SetWarnHandler(func(err error) { warning = err })
+	t.Cleanup(func() { cat.SetWarnHandler(nil) })
+
+	assert.NotPanics(t, func() {
+		assert.True(t, cat.Warn(true))
+	})
+	assert.ErrorIs(t, warning, cat.ErrUnknown)
+
+	err, warnings := cat.GuardWarn(func(ct cat.Context) error {
+		ext := ct.(cat.ExtendedContext)
+		ext.Warn(true)
+		cat.CatchHere(true)
+		return nil
+	})
+	assert.ErrorIs(t, warnings[0], cat.ErrUnknown)
+	assert.ErrorContains(t, err, "TestWarnRequireProblem")
+
+	// Catch is still strict.
+	assert.Panics(t, func() { cat.Catch(true) })
+}